@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// loader - baca nilai dari viper dengan parsing ketat. viper.GetInt/GetDuration mengembalikan 0
+// untuk nilai yang salah ketik, di sini kesalahannya dikumpulkan supaya bisa dilaporkan.
+type loader struct {
+	v      *viper.Viper
+	errs   []error
+	failed map[string]bool
+}
+
+func (l *loader) fail(key, format string, args ...any) {
+	if l.failed == nil {
+		l.failed = make(map[string]bool)
+	}
+	l.failed[key] = true
+	l.errs = append(l.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+}
+
+func (l *loader) str(key string) string {
+	return strings.TrimSpace(l.v.GetString(key))
+}
+
+// optional - seperti str, tapi env yang di-set kosong mematikan fitur. viper menganggap env kosong
+// tidak di-set sehingga default yang terpakai.
+func (l *loader) optional(key string) string {
+	if raw, ok := os.LookupEnv(key); ok && strings.TrimSpace(raw) == "" {
+		return ""
+	}
+	return l.str(key)
+}
+
+func (l *loader) int(key string) int {
+	raw := l.str(key)
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		l.fail(key, "harus bilangan bulat, bukan %q", raw)
+	}
+	return n
+}
+
+func (l *loader) duration(key string) time.Duration {
+	raw := l.str(key)
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		l.fail(key, "durasi tidak valid %q, contoh: 500ms, 10s, 5m", raw)
+	}
+	return d
+}
+
+func (l *loader) bool(key string) bool {
+	raw := l.str(key)
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		l.fail(key, "harus true atau false, bukan %q", raw)
+	}
+	return b
+}
+
+// list - daftar dipisah koma dari env/.env, atau list YAML
+func (l *loader) list(key string) []string {
+	var items []string
+	switch raw := l.v.Get(key).(type) {
+	case []any:
+		for _, item := range raw {
+			items = append(items, fmt.Sprint(item))
+		}
+	default:
+		items = strings.Split(l.str(key), ",")
+	}
+
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// routeTimeouts - format "METHOD /path=durasi" dipisah koma, ditimpakan ke defaultRouteTimeouts
+func (l *loader) routeTimeouts(key string) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(defaultRouteTimeouts))
+	for pattern, d := range defaultRouteTimeouts {
+		timeouts[pattern] = d
+	}
+	for _, entry := range l.list(key) {
+		pattern, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			l.fail(key, "%q harus berformat METHOD /path=durasi", entry)
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			l.fail(key, "durasi tidak valid di %q", entry)
+			continue
+		}
+		timeouts[strings.Join(strings.Fields(pattern), " ")] = d
+	}
+	return timeouts
+}
+
+func formatRouteTimeouts(timeouts map[string]time.Duration) string {
+	entries := make([]string, 0, len(timeouts))
+	for pattern, d := range timeouts {
+		entries = append(entries, pattern+"="+d.String())
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
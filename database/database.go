@@ -1,35 +1,47 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"log/slog"
 	"time"
 
 	_ "github.com/lib/pq"
 )
 
-func InitDB(connectionString string) (*sql.DB, error) {
+// PoolConfig - ukuran connection pool, diatur lewat DB_MAX_OPEN_CONNS dll.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// pingTimeout - database yang tidak bisa dihubungi saat start harus gagal cepat, bukan menggantung
+const pingTimeout = 10 * time.Second
+
+func InitDB(connectionString string, pool PoolConfig) (*sql.DB, error) {
 	// Open database
 	db, err := sql.Open("postgres", connectionString)
 	if err != nil {
 		return nil, err
 	}
 
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
 	// Test connection
-	err = db.Ping()
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	err = db.PingContext(ctx)
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
 
-	// Set connection pool settings (optional tapi recommended)
-	// db.SetMaxOpenConns(25)
-	// db.SetMaxIdleConns(5)
-
-	db.SetMaxOpenConns(10) // or lower first
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(30 * time.Minute)
-	db.SetConnMaxIdleTime(5 * time.Minute)
-
-	log.Println("Database connected successfully")
+	slog.Info("Database connected successfully",
+		"max_open_conns", pool.MaxOpenConns, "max_idle_conns", pool.MaxIdleConns)
 	return db, nil
 }
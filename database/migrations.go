@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// migrationFiles - file di database/migrations ikut di-embed, supaya binary tahu migrasi apa saja
+// yang dibutuhkan kode versi ini
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations - versi migrasi (nama file tanpa .sql) yang dibutuhkan, urut
+func Migrations() []string {
+	files, _ := fs.Glob(migrationFiles, "migrations/*.sql")
+	versions := make([]string, 0, len(files))
+	for _, f := range files {
+		versions = append(versions, strings.TrimSuffix(strings.TrimPrefix(f, "migrations/"), ".sql"))
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// PendingMigrations - migrasi yang belum tercatat di schema_migrations.
+// Jika tabelnya belum ada (016_schema_migrations belum dijalankan) semua migrasi dianggap belum.
+func PendingMigrations(ctx context.Context, db *sql.DB) ([]string, error) {
+	applied := make(map[string]bool)
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "42P01" { // undefined_table
+		return Migrations(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pending := make([]string, 0)
+	for _, version := range Migrations() {
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,33 @@
+-- riwayat pergerakan stok (penyesuaian, opname, penerimaan barang, dll)
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id             SERIAL PRIMARY KEY,
+    product_id     INT NOT NULL REFERENCES product(id),
+    quantity       INT NOT NULL,
+    reason         VARCHAR(32) NOT NULL,
+    reference_type VARCHAR(32),
+    reference_id   INT,
+    note           TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product ON stock_movements (product_id, created_at);
+
+-- sesi stock opname (hitung fisik)
+CREATE TABLE IF NOT EXISTS stock_takes (
+    id         SERIAL PRIMARY KEY,
+    status     VARCHAR(16) NOT NULL DEFAULT 'draft',
+    note       TEXT NOT NULL DEFAULT '',
+    posted_by  VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    posted_at  TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS stock_take_items (
+    id            SERIAL PRIMARY KEY,
+    stock_take_id INT NOT NULL REFERENCES stock_takes(id) ON DELETE CASCADE,
+    product_id    INT NOT NULL REFERENCES product(id),
+    system_stock  INT NOT NULL DEFAULT 0,
+    counted_stock INT NOT NULL,
+    reason        VARCHAR(32) NOT NULL DEFAULT '',
+    UNIQUE (stock_take_id, product_id)
+);
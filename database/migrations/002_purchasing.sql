@@ -0,0 +1,42 @@
+ALTER TABLE product ADD COLUMN IF NOT EXISTS cost_price INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS supplier (
+    id      SERIAL PRIMARY KEY,
+    name    VARCHAR(150) NOT NULL,
+    phone   VARCHAR(50) NOT NULL DEFAULT '',
+    email   VARCHAR(150) NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id          SERIAL PRIMARY KEY,
+    supplier_id INT NOT NULL REFERENCES supplier(id),
+    status      VARCHAR(16) NOT NULL DEFAULT 'open',
+    note        TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    product_id        INT NOT NULL REFERENCES product(id),
+    quantity_ordered  INT NOT NULL,
+    quantity_received INT NOT NULL DEFAULT 0,
+    unit_cost         INT NOT NULL DEFAULT 0,
+    UNIQUE (purchase_order_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id                SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders(id),
+    note              TEXT NOT NULL DEFAULT '',
+    received_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_items (
+    id               SERIAL PRIMARY KEY,
+    goods_receipt_id INT NOT NULL REFERENCES goods_receipts(id) ON DELETE CASCADE,
+    product_id       INT NOT NULL REFERENCES product(id),
+    quantity         INT NOT NULL,
+    unit_cost        INT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,3 @@
+-- min_stock 0 = tidak ada peringatan stok menipis
+ALTER TABLE product ADD COLUMN IF NOT EXISTS min_stock INT NOT NULL DEFAULT 0;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS reorder_qty INT NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS product_variants (
+    id         SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    name       VARCHAR(100) NOT NULL,
+    sku        VARCHAR(64) NOT NULL UNIQUE,
+    price      INT NOT NULL,
+    stock      INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_variants_product ON product_variants (product_id);
+
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS variant_id INT REFERENCES product_variants(id);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS modifier_groups (
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    required   BOOLEAN NOT NULL DEFAULT FALSE,
+    min_select INT NOT NULL DEFAULT 0,
+    -- 0 = tanpa batas
+    max_select INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS modifier_options (
+    id       SERIAL PRIMARY KEY,
+    group_id INT NOT NULL REFERENCES modifier_groups(id) ON DELETE CASCADE,
+    name     VARCHAR(100) NOT NULL,
+    price    INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS product_modifier_groups (
+    product_id        INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    modifier_group_id INT NOT NULL REFERENCES modifier_groups(id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, modifier_group_id)
+);
+
+-- snapshot modifier yang dipilih (nama + harga saat transaksi) untuk struk dan tiket dapur
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS modifiers JSONB NOT NULL DEFAULT '[]';
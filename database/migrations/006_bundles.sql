@@ -0,0 +1,19 @@
+-- produk paket: harga paket ada di product.price, stok diambil dari komponen
+CREATE TABLE IF NOT EXISTS bundle_components (
+    bundle_product_id    INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    component_product_id INT NOT NULL REFERENCES product(id),
+    quantity             INT NOT NULL,
+    PRIMARY KEY (bundle_product_id, component_product_id)
+);
+
+-- alokasi harga paket ke tiap komponen, untuk laporan penjualan per produk
+CREATE TABLE IF NOT EXISTS transaction_bundle_components (
+    id                   SERIAL PRIMARY KEY,
+    transaction_id       INT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    bundle_product_id    INT NOT NULL REFERENCES product(id),
+    component_product_id INT NOT NULL REFERENCES product(id),
+    quantity             INT NOT NULL,
+    allocated_amount     INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_bundle_components_tx ON transaction_bundle_components (transaction_id);
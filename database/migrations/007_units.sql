@@ -0,0 +1,19 @@
+-- product.stock selalu disimpan dalam satuan dasar (base_unit)
+ALTER TABLE product ADD COLUMN IF NOT EXISTS base_unit VARCHAR(20) NOT NULL DEFAULT 'pcs';
+
+CREATE TABLE IF NOT EXISTS product_units (
+    id                SERIAL PRIMARY KEY,
+    product_id        INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    name              VARCHAR(20) NOT NULL,
+    -- jumlah satuan dasar dalam 1 satuan ini, mis. 1 dus = 24 pcs
+    conversion_factor INT NOT NULL,
+    -- harga jual per satuan ini, 0 = harga dasar x conversion_factor
+    price             INT NOT NULL DEFAULT 0,
+    UNIQUE (product_id, name)
+);
+
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit VARCHAR(20);
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS base_quantity INT;
+
+ALTER TABLE goods_receipt_items ADD COLUMN IF NOT EXISTS unit VARCHAR(20);
+ALTER TABLE goods_receipt_items ADD COLUMN IF NOT EXISTS base_quantity INT;
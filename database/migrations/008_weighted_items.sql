@@ -0,0 +1,15 @@
+-- produk timbang (kg) butuh stok & quantity desimal
+ALTER TABLE product ALTER COLUMN stock TYPE NUMERIC(14,3);
+ALTER TABLE product ADD COLUMN IF NOT EXISTS weighted BOOLEAN NOT NULL DEFAULT FALSE;
+-- jumlah digit desimal quantity yang diizinkan (0 = hanya bilangan bulat)
+ALTER TABLE product ADD COLUMN IF NOT EXISTS quantity_precision INT NOT NULL DEFAULT 0;
+-- kode item 5 digit pada barcode timbangan EAN-13 (prefix 20-29)
+ALTER TABLE product ADD COLUMN IF NOT EXISTS plu VARCHAR(5) UNIQUE;
+
+ALTER TABLE transaction_details ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE transaction_details ALTER COLUMN base_quantity TYPE NUMERIC(14,3);
+ALTER TABLE transaction_bundle_components ALTER COLUMN quantity TYPE NUMERIC(14,3);
+
+ALTER TABLE stock_movements ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE stock_take_items ALTER COLUMN system_stock TYPE NUMERIC(14,3);
+ALTER TABLE stock_take_items ALTER COLUMN counted_stock TYPE NUMERIC(14,3);
@@ -0,0 +1,13 @@
+-- harga grosir: beli >= min_quantity (satuan dasar) dalam satu baris dapat harga satuan price
+CREATE TABLE IF NOT EXISTS product_price_tiers (
+    id           SERIAL PRIMARY KEY,
+    product_id   INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    min_quantity NUMERIC(14,3) NOT NULL CHECK (min_quantity > 0),
+    price        INT NOT NULL CHECK (price >= 0),
+    UNIQUE (product_id, min_quantity)
+);
+
+-- snapshot harga satuan & tier yang dipakai saat checkout (tier bisa berubah belakangan)
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_price INT;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS tier_min_quantity NUMERIC(14,3);
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS tier_price INT;
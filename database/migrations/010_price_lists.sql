@@ -0,0 +1,32 @@
+-- daftar harga khusus (member, reseller) yang menimpa product.price
+CREATE TABLE IF NOT EXISTS price_lists (
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL UNIQUE,
+    valid_from  TIMESTAMPTZ,
+    valid_until TIMESTAMPTZ,
+    CHECK (valid_until IS NULL OR valid_from IS NULL OR valid_until > valid_from)
+);
+
+CREATE TABLE IF NOT EXISTS price_list_items (
+    price_list_id INT NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    product_id    INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    price         INT NOT NULL CHECK (price >= 0),
+    PRIMARY KEY (price_list_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS customer_groups (
+    id            SERIAL PRIMARY KEY,
+    name          VARCHAR(100) NOT NULL UNIQUE,
+    price_list_id INT REFERENCES price_lists(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS customers (
+    id       SERIAL PRIMARY KEY,
+    name     VARCHAR(150) NOT NULL,
+    phone    VARCHAR(30) NOT NULL DEFAULT '',
+    email    VARCHAR(150) NOT NULL DEFAULT '',
+    group_id INT REFERENCES customer_groups(id) ON DELETE SET NULL
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_id INT REFERENCES customers(id) ON DELETE SET NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS price_list_id INT REFERENCES price_lists(id) ON DELETE SET NULL;
@@ -0,0 +1,28 @@
+-- riwayat harga jual: satu baris setiap product.price berubah
+CREATE TABLE IF NOT EXISTS product_price_history (
+    id          SERIAL PRIMARY KEY,
+    product_id  INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    old_price   INT,
+    new_price   INT NOT NULL,
+    source      VARCHAR(20) NOT NULL, -- initial, create, update, schedule
+    schedule_id INT,
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_price_history_product ON product_price_history (product_id, changed_at);
+
+-- perubahan harga terjadwal, diterapkan oleh scheduler saat effective_at tercapai
+CREATE TABLE IF NOT EXISTS product_price_schedules (
+    id           SERIAL PRIMARY KEY,
+    product_id   INT NOT NULL REFERENCES product(id) ON DELETE CASCADE,
+    price        INT NOT NULL CHECK (price >= 0),
+    effective_at TIMESTAMPTZ NOT NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, applied, cancelled
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    applied_at   TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_price_schedules_due ON product_price_schedules (effective_at) WHERE status = 'pending';
+
+-- harga saat migrasi dijalankan jadi titik awal timeline
+INSERT INTO product_price_history (product_id, new_price, source)
+SELECT p.id, p.price, 'initial' FROM product p
+WHERE NOT EXISTS (SELECT 1 FROM product_price_history h WHERE h.product_id = p.id);
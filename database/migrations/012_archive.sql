@@ -0,0 +1,7 @@
+-- arsip (soft delete): produk & kategori tidak dihapus supaya riwayat transaksi tetap utuh
+ALTER TABLE product ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+ALTER TABLE category ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE category ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_product_active ON product (name) WHERE NOT archived;
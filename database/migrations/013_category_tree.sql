@@ -0,0 +1,3 @@
+-- kategori bertingkat, mis. Minuman > Kopi > Espresso
+ALTER TABLE category ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES category(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_category_parent ON category (parent_id);
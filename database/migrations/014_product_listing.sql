@@ -0,0 +1,4 @@
+-- index untuk filter & sort daftar produk
+CREATE INDEX IF NOT EXISTS idx_product_category ON product (category_id) WHERE NOT archived;
+CREATE INDEX IF NOT EXISTS idx_product_price ON product (price, id) WHERE NOT archived;
+CREATE INDEX IF NOT EXISTS idx_product_stock ON product (stock, id) WHERE NOT archived;
@@ -0,0 +1,12 @@
+-- pencarian produk untuk kasir: trigram (typo & substring) + full-text (prefix saat mengetik)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS sku VARCHAR(64) UNIQUE;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS barcode VARCHAR(32) UNIQUE;
+
+CREATE INDEX IF NOT EXISTS idx_product_name_trgm ON product USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_product_name_fts ON product USING gin (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS idx_product_sku_trgm ON product USING gin (sku gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_product_barcode_prefix ON product (barcode text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_variant_sku_trgm ON product_variants USING gin (sku gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_category_name_trgm ON category USING gin (name gin_trgm_ops);
@@ -0,0 +1,40 @@
+-- catatan migrasi yang sudah dijalankan, dicek /health/ready.
+-- Setiap file migrasi berikutnya diakhiri INSERT versinya sendiri ke tabel ini.
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    VARCHAR(255) PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 001-015 belum mencatat dirinya sendiri: versi hanya dicatat jika objek terakhir yang dibuat
+-- file tersebut ada, migrasi yang terlewat tetap dilaporkan pending oleh /health/ready
+INSERT INTO schema_migrations (version)
+SELECT m.version
+FROM (VALUES
+    ('001_stock_opname',     to_regclass('stock_take_items') IS NOT NULL),
+    ('002_purchasing',       to_regclass('goods_receipt_items') IS NOT NULL),
+    ('003_low_stock',        EXISTS (SELECT 1 FROM information_schema.columns
+                                     WHERE table_name = 'product' AND column_name = 'reorder_qty')),
+    ('004_product_variants', EXISTS (SELECT 1 FROM information_schema.columns
+                                     WHERE table_name = 'transaction_details' AND column_name = 'variant_id')),
+    ('005_modifiers',        EXISTS (SELECT 1 FROM information_schema.columns
+                                     WHERE table_name = 'transaction_details' AND column_name = 'modifiers')),
+    ('006_bundles',          to_regclass('idx_transaction_bundle_components_tx') IS NOT NULL),
+    ('007_units',            EXISTS (SELECT 1 FROM information_schema.columns
+                                     WHERE table_name = 'goods_receipt_items' AND column_name = 'base_quantity')),
+    ('008_weighted_items',   EXISTS (SELECT 1 FROM information_schema.columns
+                                     WHERE table_name = 'stock_take_items' AND column_name = 'counted_stock'
+                                       AND data_type = 'numeric')),
+    ('009_price_tiers',      EXISTS (SELECT 1 FROM information_schema.columns
+                                     WHERE table_name = 'transaction_details' AND column_name = 'tier_price')),
+    ('010_price_lists',      EXISTS (SELECT 1 FROM information_schema.columns
+                                     WHERE table_name = 'transactions' AND column_name = 'price_list_id')),
+    ('011_price_history',    to_regclass('idx_price_schedules_due') IS NOT NULL),
+    ('012_archive',          to_regclass('idx_product_active') IS NOT NULL),
+    ('013_category_tree',    to_regclass('idx_category_parent') IS NOT NULL),
+    ('014_product_listing',  to_regclass('idx_product_stock') IS NOT NULL),
+    ('015_product_search',   to_regclass('idx_category_name_trgm') IS NOT NULL)
+) AS m(version, applied)
+WHERE m.applied
+ON CONFLICT (version) DO NOTHING;
+
+INSERT INTO schema_migrations (version) VALUES ('016_schema_migrations') ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,7 @@
+-- penyesuaian stok varian dicatat di stock_movements dengan variant_id terisi,
+-- product_id tetap produk induknya
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS variant_id INT REFERENCES product_variants(id);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_variant ON stock_movements (variant_id) WHERE variant_id IS NOT NULL;
+
+INSERT INTO schema_migrations (version) VALUES ('017_variant_stock_movements') ON CONFLICT (version) DO NOTHING;
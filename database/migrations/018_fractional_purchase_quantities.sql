@@ -0,0 +1,8 @@
+-- produk timbang dibeli dan diterima dalam kg desimal, sama seperti checkout (008_weighted_items)
+ALTER TABLE purchase_order_items ALTER COLUMN quantity_ordered TYPE NUMERIC(14,3);
+ALTER TABLE purchase_order_items ALTER COLUMN quantity_received TYPE NUMERIC(14,3);
+
+ALTER TABLE goods_receipt_items ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE goods_receipt_items ALTER COLUMN base_quantity TYPE NUMERIC(14,3);
+
+INSERT INTO schema_migrations (version) VALUES ('018_fractional_purchase_quantities') ON CONFLICT (version) DO NOTHING;
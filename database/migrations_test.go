@@ -0,0 +1,46 @@
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	versions := Migrations()
+	if len(versions) == 0 {
+		t.Fatal("tidak ada migrasi yang di-embed")
+	}
+	// nomor urut tanpa lompatan dan tanpa duplikat: 001_, 002_, ...
+	for i, v := range versions {
+		if prefix := fmt.Sprintf("%03d_", i+1); !strings.HasPrefix(v, prefix) {
+			t.Errorf("migrasi ke-%d = %q, ingin berawalan %q", i+1, v, prefix)
+		}
+		if strings.HasSuffix(v, ".sql") || strings.Contains(v, "/") {
+			t.Errorf("versi %q harus nama file tanpa folder dan .sql", v)
+		}
+	}
+}
+
+func TestMigrationsRecordThemselves(t *testing.T) {
+	// /health/ready membandingkan Migrations() dengan schema_migrations: versi yang tidak pernah
+	// di-INSERT akan selalu dilaporkan pending
+	var all strings.Builder
+	files, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		b, err := migrationFiles.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all.Write(b)
+	}
+	for _, v := range Migrations() {
+		if !strings.Contains(all.String(), "('"+v+"'") {
+			t.Errorf("versi %s tidak dicatat ke schema_migrations oleh file migrasi mana pun", v)
+		}
+	}
+}
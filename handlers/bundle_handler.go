@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type BundleHandler struct {
+	service *services.BundleService
+}
+
+func NewBundleHandler(service *services.BundleService) *BundleHandler {
+	return &BundleHandler{service: service}
+}
+
+// GetAll - GET /api/v1/bundles
+func (h *BundleHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	bundles, err := h.service.GetAll(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bundles)
+}
+
+// SetComponents - PUT /api/v1/bundles/{product_id}
+func (h *BundleHandler) SetComponents(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("product_id"))
+	if err != nil {
+		invalidParam(w, r, "product_id")
+		return
+	}
+
+	var req models.BundleRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.SetComponents(r.Context(), id, req.Components)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "bundle.components_updated"))
+}
@@ -6,7 +6,6 @@ import (
 	"kasir-api/services"
 	"net/http"
 	"strconv"
-	"strings"
 )
 
 type CategoryHandler struct {
@@ -17,54 +16,42 @@ func NewCategoryHandler(service *services.CategoryService) *CategoryHandler {
 	return &CategoryHandler{service: service}
 }
 
-// HandleCategories - GET /api/produk
-func (h *CategoryHandler) HandleCategories(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetAll(w, r)
-	case http.MethodPost:
-		h.Create(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+func (h *CategoryHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	categories, err := h.service.GetAll(r.Context(), includeArchived)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
-}
 
-// HandleProductByID - GET/PUT/DELETE /api/produk/{id}
-func (h *CategoryHandler) HandleCategoryByID(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetByID(w, r)
-	case http.MethodPut:
-		h.Update(w, r)
-	case http.MethodDelete:
-		h.Delete(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categories)
 }
 
-func (h *CategoryHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	categories, err := h.service.GetAll()
+// GetTree - GET /api/v1/categories/tree
+func (h *CategoryHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	tree, err := h.service.GetTree(r.Context(), includeArchived)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(categories)
+	json.NewEncoder(w).Encode(tree)
 }
 
 func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var category models.Category
 	err := json.NewDecoder(r.Body).Decode(&category)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		invalidBody(w, r, err)
 		return
 	}
 
-	err = h.service.Create(&category)
+	err = h.service.Create(r.Context(), &category)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
@@ -73,32 +60,17 @@ func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(category)
 }
 
-// HandleProductByID - GET/PUT/DELETE /api/categories/{id}
-func (h *CategoryHandler) HandleProductByID(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetByID(w, r)
-	case http.MethodPut:
-		h.Update(w, r)
-	case http.MethodDelete:
-		h.Delete(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
-}
-
-// GetByID - GET /api/categories/{id}
+// GetByID - GET /api/v1/categories/{id}
 func (h *CategoryHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/categories/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		invalidParam(w, r, "id")
 		return
 	}
 
-	category, err := h.service.GetByID(id)
+	category, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
 		return
 	}
 
@@ -107,24 +79,23 @@ func (h *CategoryHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/categories/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		invalidParam(w, r, "id")
 		return
 	}
 
 	var category models.Category
 	err = json.NewDecoder(r.Body).Decode(&category)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		invalidBody(w, r, err)
 		return
 	}
 
 	category.ID = id
-	err = h.service.Update(&category)
+	err = h.service.Update(r.Context(), &category)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
@@ -132,23 +103,51 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(category)
 }
 
-// Delete - DELETE /api/categories/{id}
+// Delete - DELETE /api/v1/categories/{id}?mode=reject|reassign|uncategorize&target_id=,
+// kategori diarsipkan bukan dihapus
 func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/categories/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var targetID *int
+	if v := r.URL.Query().Get("target_id"); v != "" {
+		t, err := strconv.Atoi(v)
+		if err != nil {
+			invalidParam(w, r, "target_id")
+			return
+		}
+		targetID = &t
+	}
+
+	result, err := h.service.Delete(r.Context(), id, r.URL.Query().Get("mode"), targetID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	body := message(r, "category.archived")
+	body["result"] = result
+	json.NewEncoder(w).Encode(body)
+}
+
+// Restore - POST /api/v1/categories/{id}/restore
+func (h *CategoryHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		invalidParam(w, r, "id")
 		return
 	}
 
-	err = h.service.Delete(id)
+	err = h.service.Restore(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": "Category deleted successfully",
-	})
+	json.NewEncoder(w).Encode(message(r, "category.restored"))
 }
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCategoryDeleteRejectsInvalidRequest(t *testing.T) {
+	h := NewCategoryHandler(&services.CategoryService{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /categories/{id}", h.Delete)
+
+	tests := []struct {
+		name, query string
+		wantStatus  int
+		wantKey     string
+	}{
+		{name: "target_id bukan angka", query: "?mode=reassign&target_id=dua", wantStatus: http.StatusBadRequest, wantKey: "request.invalid_param"},
+		{name: "mode tidak dikenal", query: "?mode=cascade", wantStatus: http.StatusUnprocessableEntity, wantKey: "category.invalid_delete_mode"},
+		{name: "reassign tanpa target", query: "?mode=reassign", wantStatus: http.StatusUnprocessableEntity, wantKey: "category.target_required"},
+		{name: "reassign ke diri sendiri", query: "?mode=reassign&target_id=5", wantStatus: http.StatusUnprocessableEntity, wantKey: "category.target_is_self"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest("DELETE", "/categories/5"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.wantStatus)
+			}
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Key != tt.wantKey {
+				t.Errorf("key = %q, ingin %q", resp.Error.Key, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type CustomerHandler struct {
+	service *services.CustomerService
+}
+
+func NewCustomerHandler(service *services.CustomerService) *CustomerHandler {
+	return &CustomerHandler{service: service}
+}
+
+// GetAll - GET /api/v1/customers?group_id= (opsional)
+func (h *CustomerHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	groupID := 0
+	if v := r.URL.Query().Get("group_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			invalidParam(w, r, "group_id")
+			return
+		}
+		groupID = id
+	}
+
+	customers, err := h.service.GetAll(r.Context(), groupID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(customers)
+}
+
+func (h *CustomerHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var customer models.Customer
+	err := json.NewDecoder(r.Body).Decode(&customer)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.Create(r.Context(), &customer)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(customer)
+}
+
+// GetByID - GET /api/v1/customers/{id}
+func (h *CustomerHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	customer, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(customer)
+}
+
+func (h *CustomerHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var customer models.Customer
+	err = json.NewDecoder(r.Body).Decode(&customer)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	customer.ID = id
+	err = h.service.Update(r.Context(), &customer)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(customer)
+}
+
+// Delete - DELETE /api/v1/customers/{id}
+func (h *CustomerHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "customer.deleted"))
+}
+
+func (h *CustomerHandler) GetAllGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.service.GetAllGroups(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+func (h *CustomerHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var group models.CustomerGroup
+	err := json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.CreateGroup(r.Context(), &group)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(group)
+}
+
+// GetGroupByID - GET /api/v1/customer-groups/{id}
+func (h *CustomerHandler) GetGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	group, err := h.service.GetGroupByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(group)
+}
+
+func (h *CustomerHandler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var group models.CustomerGroup
+	err = json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	group.ID = id
+	err = h.service.UpdateGroup(r.Context(), &group)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(group)
+}
+
+// DeleteGroup - DELETE /api/v1/customer-groups/{id}
+func (h *CustomerHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.DeleteGroup(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "customer_group.deleted"))
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/i18n"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+)
+
+type HealthHandler struct {
+	service *services.HealthService
+}
+
+func NewHealthHandler(service *services.HealthService) *HealthHandler {
+	return &HealthHandler{service: service}
+}
+
+// Live - GET /health/live, proses masih jalan dan bisa menjawab request (tanpa cek database)
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(models.HealthReport{Status: models.HealthUp})
+}
+
+// Ready - GET /health/ready, 503 jika database tidak bisa dihubungi atau ada migrasi yang belum dijalankan
+// supaya load balancer berhenti mengirim request ke instance ini. Pool hanya dilaporkan, tidak pernah 503.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	l := lang(r)
+	report := localizeHealth(h.service.Ready(r.Context()), l)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", string(l))
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != models.HealthUp {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// localizeHealth - isi pesan error tiap komponen dari key-nya
+func localizeHealth(report models.HealthReport, l i18n.Lang) models.HealthReport {
+	for name, c := range report.Components {
+		if c.Key != "" {
+			c.Error = i18n.T(l, c.Key, nil)
+			report.Components[name] = c
+		}
+	}
+	return report
+}
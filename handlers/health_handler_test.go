@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"kasir-api/i18n"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLocalizeHealth(t *testing.T) {
+	newReport := func() models.HealthReport {
+		return models.HealthReport{Status: models.HealthDown, Components: map[string]models.HealthCheck{
+			"database":   {Status: models.HealthUp},
+			"migrations": {Status: models.HealthDown, Key: "health.migrations_pending"},
+		}}
+	}
+
+	tests := []struct {
+		lang i18n.Lang
+		want string
+	}{
+		{i18n.ID, "ada migrasi yang belum dijalankan"},
+		{i18n.EN, "there are migrations that have not been run"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			report := localizeHealth(newReport(), tt.lang)
+			if got := report.Components["migrations"].Error; got != tt.want {
+				t.Errorf("migrations.error = %q, ingin %q", got, tt.want)
+			}
+			if got := report.Components["database"].Error; got != "" {
+				t.Errorf("database.error = %q, komponen up tidak boleh punya error", got)
+			}
+		})
+	}
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	db, err := sql.Open("postgres", "host=127.0.0.1 port=1 user=kasir dbname=kasir sslmode=disable connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	h := NewHealthHandler(services.NewHealthService(db, time.Second))
+
+	// live tidak menyentuh database
+	rec := httptest.NewRecorder()
+	h.Live(rec, httptest.NewRequest("GET", "/health/live", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("live: status = %d, Cache-Control = %q", rec.Code, rec.Header().Get("Cache-Control"))
+	}
+
+	r := httptest.NewRequest("GET", "/health/ready", nil)
+	r.Header.Set("Accept-Language", "en")
+	rec = httptest.NewRecorder()
+	h.Ready(rec, r)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("ready: status = %d, ingin 503", rec.Code)
+	}
+	if rec.Header().Get("Content-Language") != "en" || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("header = %v", rec.Header())
+	}
+	var report models.HealthReport
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatal(err)
+	}
+	if c := report.Components["database"]; c.Key != "health.ping_failed" || c.Error != i18n.T(i18n.EN, "health.ping_failed", nil) {
+		t.Errorf("database = %+v", c)
+	}
+}
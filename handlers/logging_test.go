@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"kasir-api/logging"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureLogs - arahkan slog default ke buf selama test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	old := slog.Default()
+	var buf bytes.Buffer
+	logging.Setup(&buf, "debug")
+	t.Cleanup(func() {
+		slog.SetDefault(old)
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	})
+	return &buf
+}
+
+func TestValidRequestID(t *testing.T) {
+	tests := map[string]bool{
+		"9f86d081884c7d65":                     true,
+		"lb-2026.10.18_abc":                    true,
+		"":                                     false,
+		"ada spasi":                            false,
+		"baris\nbaru":                          false,
+		`"kutip"`:                              false,
+		strings.Repeat("a", 128):               true,
+		strings.Repeat("a", 129):               false,
+		"0f8fad5b-d9cb-469f-a165-70867728950e": true,
+	}
+	for id, want := range tests {
+		if got := validRequestID(id); got != want {
+			t.Errorf("validRequestID(%q) = %v, ingin %v", id, got, want)
+		}
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var ctxID string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = logging.RequestID(r.Context())
+	}))
+
+	tests := []struct {
+		name     string
+		header   string
+		clientID bool // true = ID dari client dipakai
+	}{
+		{name: "dari load balancer", header: "lb-123", clientID: true},
+		{name: "tanpa header", header: ""},
+		{name: "tidak valid", header: "id palsu\ninjeksi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/produk", nil)
+			if tt.header != "" {
+				r.Header.Set(requestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			got := rec.Header().Get(requestIDHeader)
+			if got != ctxID {
+				t.Errorf("header %q berbeda dengan context %q", got, ctxID)
+			}
+			if tt.clientID && got != tt.header {
+				t.Errorf("ID = %q, ingin %q", got, tt.header)
+			}
+			// ID baru: 16 byte acak dalam hex
+			if !tt.clientID && (len(got) != 32 || !validRequestID(got)) {
+				t.Errorf("ID baru = %q, ingin 32 karakter hex", got)
+			}
+		})
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	first := httptest.NewRecorder()
+	h.ServeHTTP(first, r)
+	second := httptest.NewRecorder()
+	h.ServeHTTP(second, r)
+	if first.Header().Get(requestIDHeader) == second.Header().Get(requestIDHeader) {
+		t.Error("setiap request harus mendapat ID baru")
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	buf := captureLogs(t)
+
+	router := NewRouter()
+	router.Use(RequestID, AccessLog)
+	api := router.Group("/api/v1", RouteTimeouts{Default: time.Second})
+	api.Handle("GET /produk/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 7}`))
+	})
+	api.Handle("POST /checkout", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, os.ErrClosed)
+	})
+
+	tests := []struct {
+		method, path string
+		wantLevel    string
+		wantRoute    string
+		wantStatus   float64
+	}{
+		{method: "GET", path: "/api/v1/produk/7", wantLevel: "INFO", wantRoute: "GET /api/v1/produk/{id}", wantStatus: 200},
+		{method: "GET", path: "/api/v1/tidak-ada", wantLevel: "WARN", wantRoute: "", wantStatus: 404},
+		{method: "POST", path: "/api/v1/checkout", wantLevel: "ERROR", wantRoute: "POST /api/v1/checkout", wantStatus: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			buf.Reset()
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			r.Header.Set(requestIDHeader, "req-log-1")
+			r.Header.Set(userHeader, "kasir-01")
+			router.ServeHTTP(httptest.NewRecorder(), r)
+
+			// baris terakhir adalah access log, sebelumnya bisa ada log error dari handler
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(lines[len(lines)-1]), &entry); err != nil {
+				t.Fatalf("log bukan JSON: %v (%s)", err, buf)
+			}
+			if entry["msg"] != "request" || entry["level"] != tt.wantLevel {
+				t.Errorf("msg/level = %v/%v, ingin request/%s", entry["msg"], entry["level"], tt.wantLevel)
+			}
+			if entry["route"] != tt.wantRoute || entry["status"] != tt.wantStatus || entry["path"] != tt.path {
+				t.Errorf("route/status/path = %v/%v/%v", entry["route"], entry["status"], entry["path"])
+			}
+			if entry["request_id"] != "req-log-1" || entry["user"] != "kasir-01" || entry["method"] != tt.method {
+				t.Errorf("request_id/user/method = %v/%v/%v", entry["request_id"], entry["user"], entry["method"])
+			}
+			if _, ok := entry["latency_ms"].(float64); !ok {
+				t.Errorf("latency_ms tidak ada: %v", entry)
+			}
+			// log error dari handler ikut membawa request_id
+			for _, l := range lines[:len(lines)-1] {
+				if !strings.Contains(l, `"request_id":"req-log-1"`) {
+					t.Errorf("log tanpa request_id: %s", l)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"kasir-api/logging"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// userHeader - identitas kasir dari front-end / gateway, API belum punya autentikasi sendiri
+	userHeader = "X-User"
+	// totalCountHeader - jumlah semua baris yang cocok dengan filter pada daftar berhalaman
+	totalCountHeader = "X-Total-Count"
+)
+
+// RequestID - pakai X-Request-ID dari client / load balancer jika valid, jika tidak buat baru.
+// ID disimpan di context (ikut di log dan response error) dan dikirim balik di header response.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID - maksimal 128 karakter huruf, angka, "-", "_", "." supaya aman ditulis ke log
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// AccessLog - satu baris log per request: method, path, route, status, latency, user, request_id.
+// 5xx dicatat sebagai error, 4xx warn, sisanya info.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+
+		level := slog.LevelInfo
+		switch {
+		case sw.Status() >= 500:
+			level = slog.LevelError
+		case sw.Status() >= 400:
+			level = slog.LevelWarn
+		}
+		slog.LogAttrs(r.Context(), level, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			// r.Pattern diisi ServeMux, kosong untuk route yang tidak terdaftar
+			slog.String("route", r.Pattern),
+			slog.Int("status", sw.Status()),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int64("bytes", sw.bytes),
+			slog.String("user", r.Header.Get(userHeader)),
+			slog.String("remote_addr", r.RemoteAddr),
+		)
+	})
+}
+
+// Metrics - jumlah request per method, route dan status, serta histogram latency per route.
+// Label route memakai pattern (/api/v1/produk/{id}) bukan path asli supaya jumlah series tetap kecil.
+func Metrics(reg prometheus.Registerer) Middleware {
+	factory := promauto.With(reg)
+	requests := factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "kasir_http_requests_total",
+		Help: "Jumlah request HTTP per method, route dan status.",
+	}, []string{"method", "route", "status"})
+	duration := factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kasir_http_request_duration_seconds",
+		Help:    "Latency request HTTP per method dan route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+
+			method := r.Method
+			switch method {
+			case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+				http.MethodDelete, http.MethodOptions:
+			default:
+				method = "OTHER"
+			}
+			// r.Pattern "GET /api/v1/produk/{id}" -> "/api/v1/produk/{id}"
+			route := r.Pattern
+			if _, path, ok := strings.Cut(route, " "); ok {
+				route = path
+			}
+			if route == "" {
+				route = "unmatched"
+			}
+
+			requests.WithLabelValues(method, route, strconv.Itoa(sw.Status())).Inc()
+			duration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// statusWriter - catat status dan ukuran response untuk AccessLog dan Metrics
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if sw.status == 0 {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	n, err := sw.ResponseWriter.Write(b)
+	sw.bytes += int64(n)
+	return n, err
+}
+
+// Status - 200 jika handler tidak menulis apa pun
+func (sw *statusWriter) Status() int {
+	if sw.status == 0 {
+		return http.StatusOK
+	}
+	return sw.status
+}
+
+// Unwrap - supaya http.ResponseController (flush, deadline) tetap sampai ke writer asli
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// Recover - panic di handler dijawab 500 JSON, server tetap jalan
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				slog.ErrorContext(r.Context(), "panic",
+					"method", r.Method, "path", r.URL.Path, "panic", v, "stack", string(debug.Stack()))
+				writeErrorBody(w, r, http.StatusInternalServerError, codeInternal, "internal_error", nil)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MaxBodySize - tolak body request lebih dari limit byte. Content-Length yang kelebihan langsung dijawab 413,
+// body tanpa Content-Length dipotong di limit dan dijawab 413 oleh invalidBody.
+func MaxBodySize(limit int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				writeErrorBody(w, r, http.StatusRequestEntityTooLarge, codePayloadTooLarge, "request.body_too_large",
+					map[string]any{"limit": limit})
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Timeout - batasi waktu proses request lewat context, query yang masih berjalan dibatalkan
+// dan handler menjawab 504. d <= 0 berarti tanpa batas.
+func Timeout(d time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+const (
+	corsAllowMethods  = "GET, POST, PUT, DELETE"
+	corsAllowHeaders  = "Content-Type, Accept-Language, " + requestIDHeader + ", " + userHeader
+	corsExposeHeaders = requestIDHeader + ", " + totalCountHeader + ", Deprecation, Sunset, Link"
+	corsMaxAge        = 10 * time.Minute
+)
+
+// CORS - izinkan front-end dari origins memanggil API, "*" untuk semua origin.
+// Preflight OPTIONS langsung dijawab 204 tanpa masuk ke router.
+func CORS(origins []string) Middleware {
+	allowAll := slices.Contains(origins, "*")
+	return func(next http.Handler) http.Handler {
+		if len(origins) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Origin")
+			if !allowAll && !slices.Contains(origins, origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			// supaya front-end bisa membaca X-Request-ID untuk laporan error
+			w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(corsMaxAge.Seconds())))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
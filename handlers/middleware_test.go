@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	router := NewRouter()
+	router.Use(Metrics(reg))
+	api := router.Group("/api/v1", RouteTimeouts{Default: time.Second})
+	api.Handle("GET /produk/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	api.Handle("POST /checkout", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	for _, req := range []struct{ method, path string }{
+		{"GET", "/api/v1/produk/1"},
+		{"GET", "/api/v1/produk/2"},
+		{"POST", "/api/v1/checkout"},
+		{"GET", "/api/v1/produk/"},
+		{"DELETE", "/api/v1/produk/1"},
+		{"PROPFIND", "/api/v1/produk/1"},
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(req.method, req.path, nil))
+	}
+
+	// path asli tidak jadi label: /produk/1 dan /produk/2 masuk series yang sama
+	golden := `
+# HELP kasir_http_requests_total Jumlah request HTTP per method, route dan status.
+# TYPE kasir_http_requests_total counter
+kasir_http_requests_total{method="DELETE",route="unmatched",status="405"} 1
+kasir_http_requests_total{method="GET",route="/api/v1/produk/{id}",status="200"} 2
+kasir_http_requests_total{method="GET",route="unmatched",status="404"} 1
+kasir_http_requests_total{method="OTHER",route="unmatched",status="405"} 1
+kasir_http_requests_total{method="POST",route="/api/v1/checkout",status="409"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(golden), "kasir_http_requests_total"); err != nil {
+		t.Error(err)
+	}
+
+	// latency tidak deterministik, cukup cek jumlah observasi per series
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]uint64)
+	for _, f := range families {
+		if f.GetName() != "kasir_http_request_duration_seconds" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			var labels []string
+			for _, l := range m.GetLabel() {
+				labels = append(labels, l.GetValue())
+			}
+			counts[strings.Join(labels, " ")] = m.GetHistogram().GetSampleCount()
+		}
+	}
+	want := map[string]uint64{
+		"GET /api/v1/produk/{id}": 2,
+		"POST /api/v1/checkout":   1,
+		"GET unmatched":           1,
+		"DELETE unmatched":        1,
+		"OTHER unmatched":         1,
+	}
+	if len(counts) != len(want) {
+		t.Errorf("histogram series = %v, want %v", counts, want)
+	}
+	for series, n := range want {
+		if counts[series] != n {
+			t.Errorf("histogram %s = %d observasi, want %d", series, counts[series], n)
+		}
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+	var readErr error
+	h := MaxBodySize(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v map[string]any
+		if readErr = json.NewDecoder(r.Body).Decode(&v); readErr != nil {
+			invalidBody(w, r, readErr)
+		}
+	}))
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		wantStatus    int
+	}{
+		{name: "dalam batas", body: `{"a": 1}`, contentLength: 8, wantStatus: http.StatusOK},
+		{name: "content-length kelebihan", body: `{"note": "terlalu panjang"}`, contentLength: 27, wantStatus: http.StatusRequestEntityTooLarge},
+		// chunked: ukuran baru ketahuan saat body dibaca handler
+		{name: "tanpa content-length", body: `{"note": "terlalu panjang"}`, contentLength: -1, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/v1/checkout", strings.NewReader(tt.body))
+			r.ContentLength = tt.contentLength
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, ingin %d (err baca: %v)", rec.Code, tt.wantStatus, readErr)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var resp errorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				if resp.Error.Key != "request.body_too_large" || resp.Error.Details["limit"] != float64(16) {
+					t.Errorf("error = %+v", resp.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	h := Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m map[string]int
+		m["x"]++
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/produk", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, ingin 500", rec.Code)
+	}
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Key != "internal_error" {
+		t.Errorf("key = %q, ingin internal_error", resp.Error.Key)
+	}
+
+	// ErrAbortHandler diteruskan supaya net/http memutus koneksi tanpa log
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("recover() = %v, ingin http.ErrAbortHandler", v)
+		}
+	}()
+	Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
+
+func TestTimeout(t *testing.T) {
+	var hasDeadline bool
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+	})
+
+	Timeout(0)(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if hasDeadline {
+		t.Error("Timeout(0) tidak boleh memasang deadline")
+	}
+	Timeout(time.Second)(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if !hasDeadline {
+		t.Error("Timeout(1s) harus memasang deadline di context request")
+	}
+}
+
+func TestTimeoutAnswersGatewayTimeout(t *testing.T) {
+	// query yang dibatalkan lib/pq mengembalikan error biasa, bukan ctx.Err()
+	h := Timeout(time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		writeError(w, r, errors.New("pq: canceling statement due to user request"))
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/api/v1/checkout", nil))
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, ingin 504", rec.Code)
+	}
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != codeTimeout || resp.Error.Key != "request.timeout" {
+		t.Errorf("error = %+v", resp.Error)
+	}
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type ModifierHandler struct {
+	service *services.ModifierService
+}
+
+func NewModifierHandler(service *services.ModifierService) *ModifierHandler {
+	return &ModifierHandler{service: service}
+}
+
+func (h *ModifierHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.service.GetAll(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+func (h *ModifierHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var group models.ModifierGroup
+	err := json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.Create(r.Context(), &group)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(group)
+}
+
+// GetByID - GET /api/v1/modifier-groups/{id}
+func (h *ModifierHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	group, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(group)
+}
+
+func (h *ModifierHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var group models.ModifierGroup
+	err = json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	group.ID = id
+	err = h.service.Update(r.Context(), &group)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(group)
+}
+
+// Delete - DELETE /api/v1/modifier-groups/{id}
+func (h *ModifierHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "modifier_group.deleted"))
+}
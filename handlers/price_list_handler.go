@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type PriceListHandler struct {
+	service *services.PriceListService
+}
+
+func NewPriceListHandler(service *services.PriceListService) *PriceListHandler {
+	return &PriceListHandler{service: service}
+}
+
+func (h *PriceListHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	lists, err := h.service.GetAll(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lists)
+}
+
+func (h *PriceListHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var priceList models.PriceList
+	err := json.NewDecoder(r.Body).Decode(&priceList)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.Create(r.Context(), &priceList)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(priceList)
+}
+
+// GetByID - GET /api/v1/price-lists/{id}
+func (h *PriceListHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	priceList, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(priceList)
+}
+
+func (h *PriceListHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var priceList models.PriceList
+	err = json.NewDecoder(r.Body).Decode(&priceList)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	priceList.ID = id
+	err = h.service.Update(r.Context(), &priceList)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(priceList)
+}
+
+// Delete - DELETE /api/v1/price-lists/{id}
+func (h *PriceListHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "price_list.deleted"))
+}
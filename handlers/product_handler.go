@@ -4,11 +4,19 @@ import (
 	"encoding/json"
 	"kasir-api/models"
 	"kasir-api/services"
+	"log/slog"
 	"net/http"
 	"strconv"
-	"strings"
+	"time"
 )
 
+// stockOnUpdate - field stock di PUT /produk/{id} diganti POST /stock/adjustments (dengan reason),
+// setelah Sunset PUT yang mengirim stock akan ditolak
+var stockOnUpdate = Deprecation{
+	Since:  time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+	Sunset: time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+}
+
 type ProductHandler struct {
 	service *services.ProductService
 }
@@ -17,23 +25,80 @@ func NewProductHandler(service *services.ProductService) *ProductHandler {
 	return &ProductHandler{service: service}
 }
 
-// HandleProducts - GET /api/produk
-func (h *ProductHandler) HandleProducts(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetAll(w, r)
-	case http.MethodPost:
-		h.Create(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+// GetAll - GET /api/v1/produk?name=&category_id=&min_price=&max_price=&in_stock=&low_stock=
+// &include_archived=&sort=name|price|stock&order=asc|desc&limit=&offset=
+func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var err error
+	f := models.ProductFilter{
+		Name:            q.Get("name"),
+		InStock:         q.Get("in_stock") == "true",
+		LowStock:        q.Get("low_stock") == "true",
+		IncludeArchived: q.Get("include_archived") == "true",
+		Sort:            q.Get("sort"),
+		Order:           q.Get("order"),
+	}
+
+	if f.MinPrice, err = optionalInt(q.Get("min_price")); err != nil {
+		invalidParam(w, r, "min_price")
+		return
+	}
+	if f.MaxPrice, err = optionalInt(q.Get("max_price")); err != nil {
+		invalidParam(w, r, "max_price")
+		return
+	}
+	for param, dst := range map[string]*int{"category_id": &f.CategoryID, "limit": &f.Limit, "offset": &f.Offset} {
+		v, err := optionalInt(q.Get(param))
+		if err != nil {
+			invalidParam(w, r, param)
+			return
+		}
+		if v != nil {
+			*dst = *v
+		}
+	}
+
+	page, err := h.service.GetAll(r.Context(), f)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if page.Items == nil {
+		page.Items = []models.Product{}
 	}
+
+	// body tetap array seperti sebelum paging, jumlah total di header supaya client lama tidak rusak
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(totalCountHeader, strconv.Itoa(page.Total))
+	json.NewEncoder(w).Encode(page.Items)
 }
 
-func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	name := r.URL.Query().Get("name")
-	products, err := h.service.GetAll(name)
+// Search - GET /api/v1/produk/search?q=&limit=
+func (h *ProductHandler) Search(w http.ResponseWriter, r *http.Request) {
+	limit, err := optionalInt(r.URL.Query().Get("limit"))
+	if err != nil {
+		invalidParam(w, r, "limit")
+		return
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+
+	results, err := h.service.Search(r.Context(), r.URL.Query().Get("q"), *limit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// GetLowStock - GET /api/v1/produk/low-stock
+func (h *ProductHandler) GetLowStock(w http.ResponseWriter, r *http.Request) {
+	products, err := h.service.GetLowStock(r.Context())
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
@@ -45,13 +110,13 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var product models.Product
 	err := json.NewDecoder(r.Body).Decode(&product)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		invalidBody(w, r, err)
 		return
 	}
 
-	err = h.service.Create(&product)
+	err = h.service.Create(r.Context(), &product)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
@@ -60,32 +125,17 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(product)
 }
 
-// HandleProductByID - GET/PUT/DELETE /api/produk/{id}
-func (h *ProductHandler) HandleProductByID(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		h.GetByID(w, r)
-	case http.MethodPut:
-		h.Update(w, r)
-	case http.MethodDelete:
-		h.Delete(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
-}
-
-// GetByID - GET /api/produk/{id}
+// GetByID - GET /api/v1/produk/{id}
 func (h *ProductHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/produk/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		invalidParam(w, r, "id")
 		return
 	}
 
-	product, err := h.service.GetByID(id)
+	product, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
 		return
 	}
 
@@ -94,24 +144,27 @@ func (h *ProductHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/produk/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		invalidParam(w, r, "id")
 		return
 	}
 
-	var product models.Product
-	err = json.NewDecoder(r.Body).Decode(&product)
+	var data models.ProductUpdate
+	err = json.NewDecoder(r.Body).Decode(&data)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		invalidBody(w, r, err)
 		return
 	}
 
-	product.ID = id
-	err = h.service.Update(&product)
+	if data.Stock != nil {
+		stockOnUpdate.setHeaders(w.Header(), `</api/v1/stock/adjustments>; rel="alternate"`)
+		slog.InfoContext(r.Context(), "PUT produk mengirim stock (deprecated)", "product_id", id)
+	}
+
+	product, err := h.service.Update(r.Context(), id, data)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
@@ -119,23 +172,119 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(product)
 }
 
-// Delete - DELETE /api/produk/{id}
+// Delete - DELETE /api/v1/produk/{id}, produk diarsipkan bukan dihapus
 func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/api/produk/")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		invalidParam(w, r, "id")
 		return
 	}
 
-	err = h.service.Delete(id)
+	err = h.service.Delete(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": "Product deleted successfully",
-	})
+	json.NewEncoder(w).Encode(message(r, "product.archived"))
+}
+
+// Restore - POST /api/v1/produk/{id}/restore
+func (h *ProductHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Restore(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "product.restored"))
+}
+
+// GetPriceTimeline - GET /api/v1/produk/{id}/price-history
+func (h *ProductHandler) GetPriceTimeline(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	timeline, err := h.service.GetPriceTimeline(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timeline)
+}
+
+// SchedulePrice - POST /api/v1/produk/{id}/price-schedules
+func (h *ProductHandler) SchedulePrice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var schedule models.PriceSchedule
+	err = json.NewDecoder(r.Body).Decode(&schedule)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	schedule.ProductID = id
+	err = h.service.SchedulePrice(r.Context(), &schedule)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// CancelPriceSchedule - DELETE /api/v1/produk/{id}/price-schedules/{schedule_id}
+func (h *ProductHandler) CancelPriceSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	scheduleID, err := strconv.Atoi(r.PathValue("schedule_id"))
+	if err != nil {
+		invalidParam(w, r, "schedule_id")
+		return
+	}
+
+	err = h.service.CancelPriceSchedule(r.Context(), id, scheduleID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "price_schedule.cancelled"))
+}
+
+// optionalInt - parse query param angka, kosong = nil
+func optionalInt(v string) (*int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
 }
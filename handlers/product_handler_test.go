@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestArchiveEndpointsRejectInvalidID(t *testing.T) {
+	products := NewProductHandler(&services.ProductService{})
+	categories := NewCategoryHandler(&services.CategoryService{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /produk/{id}", products.Delete)
+	mux.HandleFunc("POST /produk/{id}/restore", products.Restore)
+	mux.HandleFunc("DELETE /categories/{id}", categories.Delete)
+	mux.HandleFunc("POST /categories/{id}/restore", categories.Restore)
+
+	for _, req := range []struct{ method, path string }{
+		{"DELETE", "/produk/abc"},
+		{"POST", "/produk/abc/restore"},
+		{"DELETE", "/categories/1.5"},
+		{"POST", "/categories/x/restore"},
+	} {
+		t.Run(req.method+" "+req.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(req.method, req.path, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, ingin 400", rec.Code)
+			}
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Key != "request.invalid_param" {
+				t.Errorf("key = %q, ingin request.invalid_param", resp.Error.Key)
+			}
+		})
+	}
+}
+
+func TestProductGetAllRejectsInvalidFilter(t *testing.T) {
+	h := NewProductHandler(&services.ProductService{})
+
+	tests := []struct {
+		name, query string
+		wantStatus  int
+		wantKey     string
+		wantParam   string
+	}{
+		{name: "min_price bukan angka", query: "?min_price=murah", wantStatus: http.StatusBadRequest, wantKey: "request.invalid_param", wantParam: "min_price"},
+		{name: "max_price pecahan", query: "?max_price=1.5", wantStatus: http.StatusBadRequest, wantKey: "request.invalid_param", wantParam: "max_price"},
+		{name: "category_id bukan angka", query: "?category_id=x", wantStatus: http.StatusBadRequest, wantKey: "request.invalid_param", wantParam: "category_id"},
+		{name: "limit bukan angka", query: "?limit=semua", wantStatus: http.StatusBadRequest, wantKey: "request.invalid_param", wantParam: "limit"},
+		{name: "sort tidak dikenal", query: "?sort=id", wantStatus: http.StatusUnprocessableEntity, wantKey: "product_filter.invalid_sort"},
+		{name: "offset negatif", query: "?offset=-10", wantStatus: http.StatusUnprocessableEntity, wantKey: "product_filter.negative_paging"},
+		{name: "rentang harga terbalik", query: "?min_price=9000&max_price=100", wantStatus: http.StatusUnprocessableEntity, wantKey: "product_filter.invalid_price_range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.GetAll(rec, httptest.NewRequest("GET", "/api/v1/produk"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.wantStatus)
+			}
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Key != tt.wantKey {
+				t.Errorf("key = %q, ingin %q", resp.Error.Key, tt.wantKey)
+			}
+			if tt.wantParam != "" && resp.Error.Details["param"] != tt.wantParam {
+				t.Errorf("param = %v, ingin %s", resp.Error.Details["param"], tt.wantParam)
+			}
+			if rec.Header().Get(totalCountHeader) != "" {
+				t.Errorf("%s tidak boleh dikirim pada error", totalCountHeader)
+			}
+		})
+	}
+}
+
+func TestProductSearchRejectsInvalidRequest(t *testing.T) {
+	h := NewProductHandler(&services.ProductService{})
+
+	tests := []struct {
+		name, query string
+		wantStatus  int
+		wantKey     string
+	}{
+		{name: "limit bukan angka", query: "?q=teh&limit=banyak", wantStatus: http.StatusBadRequest, wantKey: "request.invalid_param"},
+		{name: "tanpa q", query: "", wantStatus: http.StatusUnprocessableEntity, wantKey: "search.query_too_short"},
+		{name: "q satu karakter", query: "?q=a", wantStatus: http.StatusUnprocessableEntity, wantKey: "search.query_too_short"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.Search(rec, httptest.NewRequest("GET", "/api/v1/produk/search"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.wantStatus)
+			}
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Key != tt.wantKey {
+				t.Errorf("key = %q, ingin %q", resp.Error.Key, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type PurchaseOrderHandler struct {
+	service *services.PurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(service *services.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{service: service}
+}
+
+func (h *PurchaseOrderHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	orders, err := h.service.GetAll(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(orders)
+}
+
+func (h *PurchaseOrderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var po models.PurchaseOrder
+	err := json.NewDecoder(r.Body).Decode(&po)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.Create(r.Context(), &po)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(po)
+}
+
+// GetByID - GET /api/v1/purchase-orders/{id}
+func (h *PurchaseOrderHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	po, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(po)
+}
+
+// Receive - POST /api/v1/purchase-orders/{id}/receive
+func (h *PurchaseOrderHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var req models.ReceiveRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	receipt, err := h.service.Receive(r.Context(), id, req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(receipt)
+}
+
+// Cancel - POST /api/v1/purchase-orders/{id}/cancel
+func (h *PurchaseOrderHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Cancel(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "purchase_order.cancelled"))
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"kasir-api/i18n"
+	"kasir-api/logging"
+	"kasir-api/models"
+	"log/slog"
+	"net/http"
+)
+
+// errorResponse - format error untuk semua endpoint:
+// {"error": {"code": "not_found", "key": "product.not_found", "message": "...", "details": {...}, "request_id": "..."}}
+// message mengikuti Accept-Language, key dan details bisa dipakai front-end untuk menerjemahkan sendiri.
+// request_id sama dengan header X-Request-ID, untuk mencari log request tersebut.
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code      string         `json:"code"`
+	Key       string         `json:"key"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+}
+
+const (
+	codeBadRequest       = "bad_request"
+	codeMethodNotAllowed = "method_not_allowed"
+	codeRouteNotFound    = "route_not_found"
+	codeTimeout          = "timeout"
+	codePayloadTooLarge  = "payload_too_large"
+	codeInternal         = "internal_error"
+)
+
+// statusByCode - HTTP status untuk tiap jenis domain error
+var statusByCode = map[models.ErrorCode]int{
+	models.CodeNotFound:          http.StatusNotFound,
+	models.CodeConflict:          http.StatusConflict,
+	models.CodeValidation:        http.StatusUnprocessableEntity,
+	models.CodeInsufficientStock: http.StatusConflict,
+}
+
+// lang - bahasa response dari header Accept-Language
+func lang(r *http.Request) i18n.Lang {
+	return i18n.Parse(r.Header.Get("Accept-Language"))
+}
+
+func writeErrorBody(w http.ResponseWriter, r *http.Request, status int, code, key string, details map[string]any) {
+	l := lang(r)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", string(l))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: errorBody{
+		Code:      code,
+		Key:       key,
+		Message:   i18n.T(l, key, details),
+		Details:   details,
+		RequestID: logging.RequestID(r.Context()),
+	}})
+}
+
+// writeError - domain error dikirim dengan status yang sesuai,
+// error lain dicatat di log dan dikirim sebagai 500 tanpa detail internal
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var de *models.DomainError
+	if errors.As(err, &de) {
+		status, ok := statusByCode[de.Code]
+		if !ok {
+			status = http.StatusBadRequest
+		}
+		writeErrorBody(w, r, status, string(de.Code), de.Key, de.Details)
+		return
+	}
+
+	// batas waktu route habis; lib/pq mengembalikan error "canceling statement" bukan ctx.Err(),
+	// jadi yang dicek context request-nya
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		slog.WarnContext(r.Context(), "request timeout", "method", r.Method, "path", r.URL.Path, "err", err)
+		gatewayTimeout(w, r)
+		return
+	}
+	// client sudah memutus koneksi, response tidak akan terbaca
+	if errors.Is(r.Context().Err(), context.Canceled) {
+		slog.InfoContext(r.Context(), "request dibatalkan client", "method", r.Method, "path", r.URL.Path, "err", err)
+		return
+	}
+
+	slog.ErrorContext(r.Context(), "internal error", "method", r.Method, "path", r.URL.Path, "err", err)
+	writeErrorBody(w, r, http.StatusInternalServerError, codeInternal, "internal_error", nil)
+}
+
+// invalidBody - body request bukan JSON yang bisa dibaca. Body yang terpotong MaxBodySize
+// (chunked / tanpa Content-Length) dijawab 413 seperti Content-Length yang kelebihan.
+func invalidBody(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeErrorBody(w, r, http.StatusRequestEntityTooLarge, codePayloadTooLarge, "request.body_too_large",
+			map[string]any{"limit": tooLarge.Limit})
+		return
+	}
+	writeErrorBody(w, r, http.StatusBadRequest, codeBadRequest, "request.invalid_body", nil)
+}
+
+// invalidParam - parameter path / query tidak bisa dibaca (mis. ID bukan angka)
+func invalidParam(w http.ResponseWriter, r *http.Request, param string) {
+	writeErrorBody(w, r, http.StatusBadRequest, codeBadRequest, "request.invalid_param", map[string]any{"param": param})
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeErrorBody(w, r, http.StatusMethodNotAllowed, codeMethodNotAllowed, "request.method_not_allowed", nil)
+}
+
+func routeNotFound(w http.ResponseWriter, r *http.Request) {
+	writeErrorBody(w, r, http.StatusNotFound, codeRouteNotFound, "request.route_not_found", nil)
+}
+
+// gatewayTimeout - query dibatalkan karena melewati batas waktu route
+func gatewayTimeout(w http.ResponseWriter, r *http.Request) {
+	writeErrorBody(w, r, http.StatusGatewayTimeout, codeTimeout, "request.timeout", nil)
+}
+
+// message - body response sukses: {"key": "product.archived", "message": "..."}
+func message(r *http.Request, key string) map[string]any {
+	return map[string]any{
+		"key":     key,
+		"message": i18n.T(lang(r), key, nil),
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kasir-api/logging"
+	"kasir-api/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) errorBody {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, ingin application/json", ct)
+	}
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Error
+}
+
+func TestWriteErrorStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "not found", err: models.NotFound("product.not_found"), wantStatus: http.StatusNotFound, wantCode: "not_found"},
+		{name: "conflict", err: models.Conflict("category.in_use", "count", 3), wantStatus: http.StatusConflict, wantCode: "conflict"},
+		{name: "validasi", err: models.Invalid("search.query_too_short", "min", 2), wantStatus: http.StatusUnprocessableEntity, wantCode: "validation_error"},
+		{name: "stok kurang", err: models.InsufficientStock("stock.insufficient", "product", "Beras"), wantStatus: http.StatusConflict, wantCode: "insufficient_stock"},
+		{name: "dibungkus", err: fmt.Errorf("checkout: %w", models.NotFound("product.not_found")), wantStatus: http.StatusNotFound, wantCode: "not_found"},
+		{name: "kode tidak dikenal", err: &models.DomainError{Code: "teapot", Key: "product.not_found"}, wantStatus: http.StatusBadRequest, wantCode: "teapot"},
+		{name: "error internal", err: errors.New("pq: relation \"product\" does not exist"), wantStatus: http.StatusInternalServerError, wantCode: "internal_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeError(rec, httptest.NewRequest("GET", "/api/v1/produk", nil), tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.wantStatus)
+			}
+			if body := decodeError(t, rec); body.Code != tt.wantCode {
+				t.Errorf("code = %q, ingin %q", body.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWriteErrorEnvelope(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/v1/produk/7", nil)
+	r.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	r = r.WithContext(logging.WithRequestID(r.Context(), "req-123"))
+	rec := httptest.NewRecorder()
+	writeError(rec, r, models.InsufficientStock("stock.insufficient_available", "product", "Beras", "available", 2).With("product_id", 7))
+
+	if got := rec.Header().Get("Content-Language"); got != "en" {
+		t.Errorf("Content-Language = %q, ingin en", got)
+	}
+	body := decodeError(t, rec)
+	if body.Key != "stock.insufficient_available" || body.Message != "insufficient stock for Beras (2 left)" {
+		t.Errorf("key/message = %q / %q", body.Key, body.Message)
+	}
+	// angka JSON terbaca sebagai float64
+	if body.Details["product_id"] != float64(7) || body.Details["product"] != "Beras" {
+		t.Errorf("details = %v", body.Details)
+	}
+	if body.RequestID != "req-123" {
+		t.Errorf("request_id = %q, ingin req-123", body.RequestID)
+	}
+}
+
+func TestWriteErrorHidesInternalError(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/v1/produk", nil)
+	rec := httptest.NewRecorder()
+	writeError(rec, r, errors.New("pq: password authentication failed"))
+
+	if strings.Contains(rec.Body.String(), "pq:") {
+		t.Errorf("pesan internal bocor ke client: %s", rec.Body.String())
+	}
+	body := decodeError(t, rec)
+	if body.Key != "internal_error" || body.Details != nil {
+		t.Errorf("body = %+v", body)
+	}
+	// tanpa Accept-Language: bahasa default
+	if rec.Header().Get("Content-Language") != "id" || body.Message != "Terjadi kesalahan pada server" {
+		t.Errorf("Content-Language = %q, message = %q", rec.Header().Get("Content-Language"), body.Message)
+	}
+}
+
+func TestInvalidBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	invalidBody(rec, httptest.NewRequest("POST", "/", nil), &http.MaxBytesError{Limit: 1024})
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, ingin 413", rec.Code)
+	}
+	if body := decodeError(t, rec); body.Code != codePayloadTooLarge || body.Details["limit"] != float64(1024) {
+		t.Errorf("body = %+v", body)
+	}
+
+	rec = httptest.NewRecorder()
+	invalidBody(rec, httptest.NewRequest("POST", "/", nil), errors.New("unexpected EOF"))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, ingin 400", rec.Code)
+	}
+	if body := decodeError(t, rec); body.Key != "request.invalid_body" {
+		t.Errorf("key = %q, ingin request.invalid_body", body.Key)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	r := httptest.NewRequest("DELETE", "/api/v1/produk/1", nil)
+	r.Header.Set("Accept-Language", "en")
+	got := message(r, "product.archived")
+	if got["key"] != "product.archived" || got["message"] != "Product archived successfully" {
+		t.Errorf("message() = %v", got)
+	}
+}
+
+func TestWriteErrorContext(t *testing.T) {
+	// error deadline dari service dijawab 504 walau context request sendiri belum habis
+	rec := httptest.NewRecorder()
+	writeError(rec, httptest.NewRequest("GET", "/api/v1/report/hari-ini", nil), fmt.Errorf("laporan: %w", context.DeadlineExceeded))
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, ingin 504", rec.Code)
+	}
+
+	// client memutus koneksi: tidak ada response yang ditulis
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	writeError(rec, httptest.NewRequest("GET", "/api/v1/produk", nil).WithContext(ctx), errors.New("pq: canceling statement due to user request"))
+	if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+		t.Errorf("response tetap ditulis untuk request yang dibatalkan: %d %s", rec.Code, rec.Body)
+	}
+}
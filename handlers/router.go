@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Middleware - bungkus handler, mis. recover, logging, request ID
+type Middleware func(http.Handler) http.Handler
+
+// Chain - middleware pertama jadi lapisan paling luar
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// Router - http.ServeMux dengan pattern method + path (GET /produk/{id}) dan middleware global.
+// Path yang tidak terdaftar dijawab 404 dan method yang tidak didukung 405 + header Allow,
+// keduanya dalam format error JSON.
+type Router struct {
+	mux         *http.ServeMux
+	middlewares []Middleware
+}
+
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux()}
+}
+
+// Use - tambahkan middleware untuk semua route, dijalankan sesuai urutan didaftarkan
+func (rt *Router) Use(middlewares ...Middleware) {
+	rt.middlewares = append(rt.middlewares, middlewares...)
+}
+
+// Handle - daftarkan route dengan pattern lengkap, middleware tambahan hanya berlaku untuk route ini
+func (rt *Router) Handle(pattern string, h http.HandlerFunc, middlewares ...Middleware) {
+	rt.mux.Handle(pattern, Chain(h, middlewares...))
+}
+
+// RouteTimeouts - batas waktu request per route, pattern yang tidak ada di Routes memakai Default
+type RouteTimeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration // key pattern tanpa prefix group, mis. "POST /checkout"
+}
+
+func (t RouteTimeouts) For(pattern string) time.Duration {
+	if d, ok := t.Routes[pattern]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// Group - route dengan prefix yang sama (mis. /api/v1), setiap route diberi batas waktu dari timeouts
+func (rt *Router) Group(prefix string, timeouts RouteTimeouts) *RouteGroup {
+	return &RouteGroup{router: rt, prefix: strings.TrimSuffix(prefix, "/"), timeouts: timeouts}
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	Chain(http.HandlerFunc(rt.dispatch), rt.middlewares...).ServeHTTP(w, r)
+}
+
+func (rt *Router) dispatch(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := rt.mux.Handler(r); pattern == "" {
+		// tidak ada route yang cocok: ServeMux akan menulis 404/405 text/plain (dan header Allow),
+		// status-nya ditangkap lalu dijawab dengan error JSON
+		rt.mux.ServeHTTP(&unmatchedWriter{ResponseWriter: w, r: r}, r)
+		return
+	}
+	rt.mux.ServeHTTP(w, r)
+}
+
+// unmatchedWriter - ganti body 404/405 bawaan ServeMux dengan error JSON,
+// response lain (mis. redirect path yang tidak bersih) diteruskan apa adanya
+type unmatchedWriter struct {
+	http.ResponseWriter
+	r        *http.Request
+	replaced bool
+}
+
+func (uw *unmatchedWriter) WriteHeader(status int) {
+	switch status {
+	case http.StatusNotFound:
+		uw.replaced = true
+		routeNotFound(uw.ResponseWriter, uw.r)
+	case http.StatusMethodNotAllowed:
+		uw.replaced = true
+		methodNotAllowed(uw.ResponseWriter, uw.r)
+	default:
+		uw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (uw *unmatchedWriter) Write(b []byte) (int, error) {
+	if uw.replaced {
+		return len(b), nil
+	}
+	return uw.ResponseWriter.Write(b)
+}
+
+type RouteGroup struct {
+	router   *Router
+	prefix   string
+	timeouts RouteTimeouts
+	routes   map[string]route
+}
+
+type route struct {
+	handler     http.HandlerFunc
+	middlewares []Middleware
+}
+
+// Handle - pattern "GET /produk/{id}" didaftarkan sebagai "GET /api/v1/produk/{id}"
+func (g *RouteGroup) Handle(pattern string, h http.HandlerFunc, middlewares ...Middleware) {
+	if g.routes == nil {
+		g.routes = make(map[string]route)
+	}
+	g.routes[pattern] = route{handler: h, middlewares: middlewares}
+	g.register(g.prefix, pattern, h, middlewares...)
+}
+
+func (g *RouteGroup) register(prefix, pattern string, h http.HandlerFunc, middlewares ...Middleware) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+	middlewares = append([]Middleware{Timeout(g.timeouts.For(pattern))}, middlewares...)
+	g.router.Handle(strings.TrimSpace(method+" "+prefix+path), h, middlewares...)
+}
+
+// CheckTimeouts - panggil setelah semua route didaftarkan: key RouteTimeouts yang tidak cocok dengan route
+// group ini (mis. salah ketik "POST /chekout") ditolak supaya tidak diam-diam memakai timeout default
+func (g *RouteGroup) CheckTimeouts() error {
+	var unknown []string
+	for pattern := range g.timeouts.Routes {
+		if _, ok := g.routes[pattern]; !ok {
+			unknown = append(unknown, strconv.Quote(pattern))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	slices.Sort(unknown)
+	return fmt.Errorf("ROUTE_TIMEOUTS: route tidak terdaftar: %s", strings.Join(unknown, ", "))
+}
+
+// Deprecation - masa berlaku alias route lama: Since = mulai deprecated, Sunset = tanggal alias dihapus
+type Deprecation struct {
+	Since  time.Time
+	Sunset time.Time
+}
+
+// Alias - daftarkan ulang route group yang sudah ada di bawah prefix lama, mis. "GET /produk" juga dilayani
+// di /api/produk. Response alias diberi header Deprecation, Sunset dan Link ke path baru (RFC 9745, RFC 8594).
+// Pattern yang belum didaftarkan lewat Handle membuat panic saat start, sama seperti pattern ServeMux yang salah.
+func (g *RouteGroup) Alias(prefix string, dep Deprecation, patterns ...string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	for _, pattern := range patterns {
+		rt, ok := g.routes[pattern]
+		if !ok {
+			panic("handlers: alias untuk route yang tidak terdaftar: " + pattern)
+		}
+		middlewares := append([]Middleware{deprecated(dep, prefix, g.prefix)}, rt.middlewares...)
+		g.register(prefix, pattern, rt.handler, middlewares...)
+	}
+}
+
+// setHeaders - header Deprecation, Sunset dan Link ke pengganti (RFC 9745, RFC 8594)
+func (dep Deprecation) setHeaders(h http.Header, link string) {
+	h.Set("Deprecation", "@"+strconv.FormatInt(dep.Since.Unix(), 10))
+	h.Set("Sunset", dep.Sunset.UTC().Format(http.TimeFormat))
+	h.Set("Link", link)
+}
+
+// deprecated - tandai response dari path lama, Link menunjuk path yang sama di bawah prefix baru
+func deprecated(dep Deprecation, oldPrefix, newPrefix string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dep.setHeaders(w.Header(), "<"+newPrefix+strings.TrimPrefix(r.URL.Path, oldPrefix)+`>; rel="successor-version"`)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
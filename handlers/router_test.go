@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRouter() *Router {
+	router := NewRouter()
+	api := router.Group("/api/v1", RouteTimeouts{Default: time.Second})
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Pattern))
+	}
+	api.Handle("GET /produk", ok)
+	api.Handle("POST /produk", ok)
+	api.Handle("GET /produk/{id}", ok)
+	api.Handle("PUT /produk/{id}", ok)
+	api.Handle("POST /produk/{id}/restore", ok)
+	api.Alias("/api", Deprecation{
+		Since:  time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}, "GET /produk", "GET /produk/{id}")
+	return router
+}
+
+func TestRouter(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		status  int
+		code    string // error code di body JSON
+		allow   []string
+		pattern string
+	}{
+		{name: "route terdaftar", method: "GET", path: "/api/v1/produk", status: 200, pattern: "GET /api/v1/produk"},
+		{name: "path parameter", method: "PUT", path: "/api/v1/produk/7", status: 200, pattern: "PUT /api/v1/produk/{id}"},
+		{name: "HEAD ikut GET", method: "HEAD", path: "/api/v1/produk/7", status: 200},
+		{name: "trailing slash", method: "GET", path: "/api/v1/produk/", status: 404, code: codeRouteNotFound},
+		{name: "trailing slash setelah id", method: "GET", path: "/api/v1/produk/7/", status: 404, code: codeRouteNotFound},
+		{name: "segmen berlebih", method: "GET", path: "/api/v1/produk/abc/def", status: 404, code: codeRouteNotFound},
+		{name: "tanpa versi untuk route tanpa alias", method: "POST", path: "/api/produk/7/restore", status: 404, code: codeRouteNotFound},
+		{name: "path tidak dikenal", method: "GET", path: "/api/v1/tidak-ada", status: 404, code: codeRouteNotFound},
+		{name: "method tidak didukung", method: "DELETE", path: "/api/v1/produk", status: 405, code: codeMethodNotAllowed, allow: []string{"GET", "HEAD", "POST"}},
+		{name: "method tidak didukung dengan id", method: "DELETE", path: "/api/v1/produk/7", status: 405, code: codeMethodNotAllowed, allow: []string{"GET", "HEAD", "PUT"}},
+		{name: "alias hanya untuk method yang didaftarkan", method: "POST", path: "/api/produk", status: 405, code: codeMethodNotAllowed, allow: []string{"GET", "HEAD"}},
+	}
+
+	router := testRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.status, rec.Body)
+			}
+			if tt.pattern != "" && rec.Body.String() != tt.pattern {
+				t.Errorf("pattern = %q, want %q", rec.Body, tt.pattern)
+			}
+			if tt.code != "" {
+				var body errorResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("body bukan error JSON: %v (%s)", err, rec.Body)
+				}
+				if body.Error.Code != tt.code {
+					t.Errorf("code = %q, want %q", body.Error.Code, tt.code)
+				}
+			}
+			if tt.allow != nil {
+				allow := strings.Split(rec.Header().Get("Allow"), ", ")
+				if strings.Join(allow, ",") != strings.Join(tt.allow, ",") {
+					t.Errorf("Allow = %v, want %v", allow, tt.allow)
+				}
+			}
+		})
+	}
+}
+
+func TestRouterDeprecatedAlias(t *testing.T) {
+	router := testRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/api/produk/7", nil))
+	if rec.Code != 200 || rec.Body.String() != "GET /api/produk/{id}" {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	h := rec.Header()
+	if got := h.Get("Deprecation"); got != "@1790812800" {
+		t.Errorf("Deprecation = %q", got)
+	}
+	if got := h.Get("Sunset"); got != "Thu, 01 Apr 2027 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", got)
+	}
+	if got := h.Get("Link"); got != `</api/v1/produk/7>; rel="successor-version"` {
+		t.Errorf("Link = %q", got)
+	}
+
+	// path baru tidak diberi header deprecated
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/produk/7", nil))
+	if rec.Header().Get("Deprecation") != "" || rec.Header().Get("Sunset") != "" {
+		t.Errorf("route /api/v1 ikut diberi header deprecated: %v", rec.Header())
+	}
+}
+
+func TestRouteGroupTimeouts(t *testing.T) {
+	router := NewRouter()
+	api := router.Group("/api/v1", RouteTimeouts{
+		Default: 10 * time.Second,
+		Routes:  map[string]time.Duration{"GET /produk/search": 800 * time.Millisecond},
+	})
+	// sisa waktu context dikirim di body supaya bisa dibandingkan dengan timeout route
+	remaining := func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		if !ok {
+			w.Write([]byte("tanpa deadline"))
+			return
+		}
+		w.Write([]byte(time.Until(deadline).Round(100 * time.Millisecond).String()))
+	}
+	api.Handle("GET /produk", remaining)
+	api.Handle("GET /produk/search", remaining)
+
+	for path, want := range map[string]string{
+		"/api/v1/produk":        "10s",
+		"/api/v1/produk/search": "800ms",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Body.String() != want {
+			t.Errorf("%s: sisa waktu = %s, ingin %s", path, rec.Body, want)
+		}
+	}
+
+	if err := api.CheckTimeouts(); err != nil {
+		t.Errorf("CheckTimeouts: %v", err)
+	}
+}
+
+func TestCheckTimeoutsRejectsUnknownRoute(t *testing.T) {
+	api := NewRouter().Group("/api/v1", RouteTimeouts{
+		Default: time.Second,
+		Routes: map[string]time.Duration{
+			"POST /chekout":    15 * time.Second,
+			"GET /produk/cari": time.Second,
+			"GET /produk":      2 * time.Second,
+		},
+	})
+	api.Handle("GET /produk", func(w http.ResponseWriter, r *http.Request) {})
+
+	err := api.CheckTimeouts()
+	if err == nil {
+		t.Fatal("salah ketik di ROUTE_TIMEOUTS harus ditolak")
+	}
+	if want := `ROUTE_TIMEOUTS: route tidak terdaftar: "GET /produk/cari", "POST /chekout"`; err.Error() != want {
+		t.Errorf("err = %q, ingin %q", err, want)
+	}
+}
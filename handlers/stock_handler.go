@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type StockHandler struct {
+	service *services.StockService
+}
+
+func NewStockHandler(service *services.StockService) *StockHandler {
+	return &StockHandler{service: service}
+}
+
+// Adjust - POST /api/v1/stock/adjustments
+func (h *StockHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	var req models.StockAdjustmentRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	movement, err := h.service.Adjust(r.Context(), req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(movement)
+}
+
+// Movements - GET /api/v1/stock/movements?product_id=
+func (h *StockHandler) Movements(w http.ResponseWriter, r *http.Request) {
+	productID := 0
+	if v := r.URL.Query().Get("product_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			invalidParam(w, r, "product_id")
+			return
+		}
+		productID = id
+	}
+
+	movements, err := h.service.GetMovements(r.Context(), productID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(movements)
+}
+
+func (h *StockHandler) GetAllStockTakes(w http.ResponseWriter, r *http.Request) {
+	stockTakes, err := h.service.GetAllStockTakes(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stockTakes)
+}
+
+func (h *StockHandler) CreateStockTake(w http.ResponseWriter, r *http.Request) {
+	var st models.StockTake
+	err := json.NewDecoder(r.Body).Decode(&st)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.CreateStockTake(r.Context(), &st)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(st)
+}
+
+// GetStockTakeByID - GET /api/v1/stock-opname/{id}
+func (h *StockHandler) GetStockTakeByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	st, err := h.service.GetStockTakeByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+// SubmitCounts - POST /api/v1/stock-opname/{id}/items
+func (h *StockHandler) SubmitCounts(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var req models.StockCountRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	st, err := h.service.SubmitCounts(r.Context(), id, req.Items)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+// PostStockTake - POST /api/v1/stock-opname/{id}/post
+func (h *StockHandler) PostStockTake(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var req models.PostStockTakeRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	st, err := h.service.PostStockTake(r.Context(), id, req.PostedBy)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStockAdjustRejectsInvalidRequest(t *testing.T) {
+	// validasi terjadi sebelum repository dipanggil
+	h := NewStockHandler(&services.StockService{})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantKey    string
+	}{
+		{name: "bukan json", body: "{", wantStatus: http.StatusBadRequest, wantKey: "request.invalid_body"},
+		{name: "tanpa product_id", body: `{"quantity": -1, "reason": "damaged"}`, wantStatus: http.StatusUnprocessableEntity, wantKey: "validation.product_id_required"},
+		{name: "rusak menambah stok", body: `{"product_id": 1, "quantity": 2, "reason": "damaged"}`, wantStatus: http.StatusUnprocessableEntity, wantKey: "stock.reason_requires_negative"},
+		{name: "ditemukan mengurangi stok", body: `{"product_id": 1, "variant_id": 3, "quantity": -2, "reason": "found"}`, wantStatus: http.StatusUnprocessableEntity, wantKey: "stock.reason_requires_positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.Adjust(rec, httptest.NewRequest("POST", "/api/v1/stock/adjustments", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.wantStatus)
+			}
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Key != tt.wantKey {
+				t.Errorf("key = %q, ingin %q", resp.Error.Key, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type SupplierHandler struct {
+	service *services.SupplierService
+}
+
+func NewSupplierHandler(service *services.SupplierService) *SupplierHandler {
+	return &SupplierHandler{service: service}
+}
+
+func (h *SupplierHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	suppliers, err := h.service.GetAll(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suppliers)
+}
+
+func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var supplier models.Supplier
+	err := json.NewDecoder(r.Body).Decode(&supplier)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.Create(r.Context(), &supplier)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(supplier)
+}
+
+// GetByID - GET /api/v1/suppliers/{id}
+func (h *SupplierHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	supplier, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supplier)
+}
+
+func (h *SupplierHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var supplier models.Supplier
+	err = json.NewDecoder(r.Body).Decode(&supplier)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	supplier.ID = id
+	err = h.service.Update(r.Context(), &supplier)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supplier)
+}
+
+// Delete - DELETE /api/v1/suppliers/{id}
+func (h *SupplierHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "supplier.deleted"))
+}
@@ -1,12 +1,11 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
+	"fmt"
 	"kasir-api/models"
 	"kasir-api/services"
 	"net/http"
-	"time"
 )
 
 type TransactionHandler struct {
@@ -17,27 +16,18 @@ func NewTransactionHandler(service *services.TransactionService) *TransactionHan
 	return &TransactionHandler{service: service}
 }
 
-// multiple item apa aja, quantity nya
-func (h *TransactionHandler) HandleCheckout(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodPost:
-		h.Checkout(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
-}
-
+// Checkout - POST /api/v1/checkout, multiple item apa aja, quantity nya
 func (h *TransactionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
 	var req models.CheckoutRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		invalidBody(w, r, err)
 		return
 	}
 
-	transaction, err := h.service.Checkout(req.Items)
+	transaction, err := h.service.Checkout(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -45,18 +35,11 @@ func (h *TransactionHandler) Checkout(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(transaction)
 }
 
+// SummaryToday - GET /api/v1/report/hari-ini
 func (h *TransactionHandler) SummaryToday(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
-	defer cancel()
-
-	summary, err := h.service.GetSummaryToday(ctx)
+	summary, err := h.service.GetSummaryToday(r.Context())
 	if err != nil {
-		http.Error(w, "failed to get today summary: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, fmt.Errorf("failed to get today summary: %w", err))
 		return
 	}
 
@@ -68,7 +51,7 @@ func (h *TransactionHandler) SummaryToday(w http.ResponseWriter, r *http.Request
 	bpNama := ""
 	bpQty := 0
 	{
-		nama, qty, e := h.service.GetBestSellerToday(ctx)
+		nama, qty, e := h.service.GetBestSellerToday(r.Context())
 		if e == nil {
 			bpNama = nama
 			bpQty = qty
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type UnitHandler struct {
+	service *services.UnitService
+}
+
+func NewUnitHandler(service *services.UnitService) *UnitHandler {
+	return &UnitHandler{service: service}
+}
+
+// GetByProduct - GET /api/v1/units?product_id=
+func (h *UnitHandler) GetByProduct(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(r.URL.Query().Get("product_id"))
+	if err != nil {
+		invalidParam(w, r, "product_id")
+		return
+	}
+
+	units, err := h.service.GetByProduct(r.Context(), productID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(units)
+}
+
+func (h *UnitHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var unit models.ProductUnit
+	err := json.NewDecoder(r.Body).Decode(&unit)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.Create(r.Context(), &unit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(unit)
+}
+
+// GetByID - GET /api/v1/units/{id}
+func (h *UnitHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	unit, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(unit)
+}
+
+func (h *UnitHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var unit models.ProductUnit
+	err = json.NewDecoder(r.Body).Decode(&unit)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	unit.ID = id
+	err = h.service.Update(r.Context(), &unit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(unit)
+}
+
+// Delete - DELETE /api/v1/units/{id}
+func (h *UnitHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "unit.deleted"))
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/models"
+	"kasir-api/services"
+	"net/http"
+	"strconv"
+)
+
+type VariantHandler struct {
+	service *services.VariantService
+}
+
+func NewVariantHandler(service *services.VariantService) *VariantHandler {
+	return &VariantHandler{service: service}
+}
+
+// GetByProduct - GET /api/v1/variants?product_id=
+func (h *VariantHandler) GetByProduct(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.Atoi(r.URL.Query().Get("product_id"))
+	if err != nil {
+		invalidParam(w, r, "product_id")
+		return
+	}
+
+	variants, err := h.service.GetByProduct(r.Context(), productID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(variants)
+}
+
+func (h *VariantHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var variant models.ProductVariant
+	err := json.NewDecoder(r.Body).Decode(&variant)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	err = h.service.Create(r.Context(), &variant)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(variant)
+}
+
+// GetByID - GET /api/v1/variants/{id}
+func (h *VariantHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	variant, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(variant)
+}
+
+func (h *VariantHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	var variant models.ProductVariant
+	err = json.NewDecoder(r.Body).Decode(&variant)
+	if err != nil {
+		invalidBody(w, r, err)
+		return
+	}
+
+	variant.ID = id
+	err = h.service.Update(r.Context(), &variant)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(variant)
+}
+
+// Delete - DELETE /api/v1/variants/{id}
+func (h *VariantHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		invalidParam(w, r, "id")
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(message(r, "variant.deleted"))
+}
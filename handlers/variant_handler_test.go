@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"kasir-api/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVariantHandlerRejectsInvalidRequest(t *testing.T) {
+	h := NewVariantHandler(&services.VariantService{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /variants", h.Create)
+	mux.HandleFunc("PUT /variants/{id}", h.Update)
+
+	tests := []struct {
+		name, method, path, body string
+		wantStatus               int
+		wantKey                  string
+	}{
+		{name: "id bukan angka", method: "PUT", path: "/variants/abc", body: `{}`, wantStatus: http.StatusBadRequest, wantKey: "request.invalid_param"},
+		{name: "body rusak", method: "POST", path: "/variants", body: `{"name":`, wantStatus: http.StatusBadRequest, wantKey: "request.invalid_body"},
+		{name: "sku kosong", method: "PUT", path: "/variants/1", body: `{"name": "L", "sku": " "}`, wantStatus: http.StatusUnprocessableEntity, wantKey: "variant.sku_required"},
+		{name: "harga negatif", method: "POST", path: "/variants", body: `{"product_id": 1, "name": "L", "sku": "TS-L", "price": -1}`, wantStatus: http.StatusUnprocessableEntity, wantKey: "product.price_stock_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.wantStatus)
+			}
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Key != tt.wantKey {
+				t.Errorf("key = %q, ingin %q", resp.Error.Key, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package i18n - katalog pesan API dalam bahasa Indonesia dan Inggris.
+// Pesan dicari lewat key (mis. "product.not_found"), placeholder {nama} diisi dari params.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Lang string
+
+const (
+	ID Lang = "id"
+	EN Lang = "en"
+
+	// Default - bahasa jika Accept-Language kosong atau tidak didukung
+	Default = ID
+)
+
+var catalogs = map[Lang]map[string]string{
+	ID: messagesID,
+	EN: messagesEN,
+}
+
+// T - terjemahkan key ke bahasa lang. Key yang belum ada di bahasa tersebut memakai bahasa default,
+// key yang tidak ada sama sekali dikembalikan apa adanya supaya tetap kelihatan di response.
+func T(lang Lang, key string, params map[string]any) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	for name, value := range params {
+		msg = strings.ReplaceAll(msg, "{"+name+"}", formatParam(value))
+	}
+	return msg
+}
+
+func formatParam(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Parse - pilih bahasa dari header Accept-Language (mis. "en-US,en;q=0.9,id;q=0.8").
+// Bahasa dengan q tertinggi yang didukung menang, urutan header dipakai jika q sama.
+func Parse(header string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		switch primary {
+		case "id", "in": // "in" kode lama untuk bahasa Indonesia
+			candidates = append(candidates, candidate{ID, q})
+		case "en":
+			candidates = append(candidates, candidate{EN, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
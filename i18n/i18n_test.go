@@ -0,0 +1,37 @@
+package i18n
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Lang
+	}{
+		{"", Default},
+		{"en", EN},
+		{"id", ID},
+		{"en-US", EN},
+		{"id-ID", ID},
+		{"EN-gb", EN},
+		{"in", ID},
+		{"en-US,en;q=0.9,id;q=0.8", EN},
+		{"id;q=0.8,en;q=0.9", EN},
+		{"fr-FR,fr;q=0.9,en;q=0.5", EN},
+		{"fr, de", Default},
+		{"*", Default},
+		// q sama: urutan header yang menang
+		{"en;q=0.5, id;q=0.5", EN},
+		{"id, en", ID},
+		// q=0 artinya tidak mau bahasa itu
+		{"en;q=0, id;q=0.1", ID},
+		{"en;q=0", Default},
+		// q yang tidak bisa dibaca dilewati
+		{"en;q=abc, id;q=0.2", ID},
+		{"  en-US ; q=0.7 ,  id ; q=0.6 ", EN},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.header); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.header, got, tt.want)
+		}
+	}
+}
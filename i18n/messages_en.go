@@ -0,0 +1,176 @@
+package i18n
+
+var messagesEN = map[string]string{
+	// request & server
+	"request.invalid_body":       "Invalid request body",
+	"request.body_too_large":     "Request body exceeds the {limit} byte limit",
+	"request.invalid_param":      "Invalid {param}",
+	"request.method_not_allowed": "Method not allowed",
+	"request.route_not_found":    "Route not found",
+	"request.timeout":            "Request timed out",
+	"internal_error":             "Internal server error",
+
+	// health check
+	"health.database_unreachable":  "database is unreachable",
+	"health.ping_failed":           "database ping failed",
+	"health.migrations_unreadable": "failed to read schema_migrations",
+	"health.migrations_pending":    "there are migrations that have not been run",
+
+	// general validation
+	"validation.product_id_required":   "product_id is required",
+	"validation.items_empty":           "items must not be empty",
+	"validation.price_negative":        "price must not be negative",
+	"validation.quantity_not_positive": "quantity for product id {product_id} must be greater than 0",
+	"validation.quantity_precision":    "quantity for product {product} allows at most {precision} decimal places",
+	"validation.product_duplicate":     "product id {product_id} appears more than once",
+
+	// products
+	"product.not_found":                  "product not found",
+	"product.id_not_found":               "product id {product_id} not found",
+	"product.plu_not_found":              "product with plu {plu} not found",
+	"product.price_stock_negative":       "price and stock must not be negative",
+	"product.min_stock_negative":         "min_stock and reorder_qty must not be negative",
+	"product.invalid_precision":          "quantity_precision must be between 0 and {max}",
+	"product.stock_precision":            "stock allows at most {precision} decimal places",
+	"product.invalid_plu":                "plu must be 5 digits",
+	"product.plu_taken":                  "plu is already used by another product",
+	"product.weighted_has_variants":      "product with variants cannot be a weighted product",
+	"product.sku_taken":                  "sku is already used by another product",
+	"product.barcode_taken":              "barcode is already used by another product",
+	"product.archived":                   "Product archived successfully",
+	"product.restored":                   "Product restored successfully",
+	"product_filter.invalid_sort":        "sort must be name, price or stock",
+	"product_filter.invalid_order":       "order must be asc or desc",
+	"product_filter.invalid_price_range": "min_price must not be greater than max_price",
+	"product_filter.negative_paging":     "limit and offset must not be negative",
+	"search.query_too_short":             "q must be at least {min} characters",
+
+	// price tiers, price history & schedules
+	"price_tier.min_quantity_not_positive": "price tier min_quantity must be greater than 0",
+	"price_tier.min_quantity_precision":    "price tier min_quantity allows at most {precision} decimal places",
+	"price_tier.duplicate":                 "duplicate min_quantity {min_quantity}",
+	"price_tier.invalid_price":             "price tier for min {min_quantity} must be between 0 and the regular price",
+	"price_schedule.not_found":             "price schedule not found",
+	"price_schedule.not_pending":           "price schedule is already {status}",
+	"price_schedule.effective_at_required": "effective_at is required",
+	"price_schedule.effective_at_past":     "effective_at must be in the future",
+	"price_schedule.cancelled":             "Price schedule cancelled successfully",
+
+	// categories
+	"category.not_found":           "category not found",
+	"category.parent_not_found":    "parent category not found",
+	"category.parent_cycle":        "parent_id must not be the category itself or one of its descendants",
+	"category.target_not_found":    "target category not found",
+	"category.target_archived":     "target category is archived",
+	"category.target_required":     "target_id is required for reassign mode",
+	"category.target_is_self":      "target_id must not be the category being deleted",
+	"category.invalid_delete_mode": "mode must be reject, reassign or uncategorize",
+	"category.in_use":              "category is still used by {count} products",
+	"category.archived":            "Category archived successfully",
+	"category.restored":            "Category restored successfully",
+
+	// variants, units, modifiers, bundles
+	"variant.not_found":             "variant not found",
+	"variant.not_found_for_product": "variant id {variant_id} not found for product id {product_id}",
+	"variant.name_required":         "variant name is required",
+	"variant.sku_required":          "sku is required",
+	"variant.sku_taken":             "sku is already used by another variant",
+	"variant.weighted_product":      "weighted products cannot have variants",
+	"variant.bundle_product":        "a bundle product cannot have variants",
+	"variant.bundle_component":      "a product used as a bundle component cannot have variants",
+	"variant.in_use":                "variant has transaction or stock history and cannot be deleted",
+	"variant.deleted":               "Variant deleted successfully",
+
+	"unit.not_found":                 "unit not found",
+	"unit.not_found_for_product":     "unit \"{unit}\" not found for product id {product_id}",
+	"unit.name_required":             "unit name is required",
+	"unit.name_taken":                "unit name already exists for this product",
+	"unit.invalid_conversion_factor": "conversion_factor must be greater than 1",
+	"unit.deleted":                   "Unit deleted successfully",
+
+	"modifier_group.not_found":           "modifier group not found",
+	"modifier_group.name_required":       "modifier group name is required",
+	"modifier_group.options_empty":       "options must not be empty",
+	"modifier_group.select_negative":     "min_select and max_select must not be negative",
+	"modifier_group.min_exceeds_max":     "min_select must not be greater than max_select",
+	"modifier_group.min_exceeds_options": "min_select {min} exceeds the number of options",
+	"modifier_group.deleted":             "Modifier group deleted successfully",
+	"modifier_option.not_found":          "modifier option id {option_id} not found in this group",
+	"modifier_option.name_required":      "modifier option name is required",
+	"modifier_option.price_negative":     "price of option {option} must not be negative",
+
+	"bundle.contains_itself":                 "a bundle cannot contain itself",
+	"bundle.product_has_variants":            "a product with variants cannot be a bundle",
+	"bundle.product_is_component":            "this product is a component of another bundle and cannot be a bundle",
+	"bundle.component_product_required":      "component product_id is required",
+	"bundle.component_quantity_not_positive": "component quantity for product id {product_id} must be greater than 0",
+	"bundle.component_has_variants":          "product id {product_id} has variants and cannot be a bundle component",
+	"bundle.component_archived":              "product id {product_id} is archived and cannot be a bundle component",
+	"bundle.component_is_bundle":             "product id {product_id} is a bundle, bundles cannot contain bundles",
+	"bundle.components_updated":              "Bundle components updated successfully",
+
+	// checkout & scale barcodes
+	"checkout.component_archived":       "bundle {bundle} cannot be sold because component {product} is archived",
+	"checkout.product_archived":         "product {product} is archived and cannot be sold",
+	"checkout.variant_required":         "product {product} has variants, variant_id is required",
+	"checkout.variant_weighted":         "weighted product {product} cannot be sold as a variant",
+	"checkout.unit_not_supported":       "unit is not supported for weighted, variant or bundle product {product}",
+	"checkout.not_weighted":             "product {product} is not a weighted product",
+	"checkout.product_id_with_barcode":  "send either product_id or barcode, not both (product_id {product_id}, barcode {barcode})",
+	"checkout.quantity_not_whole":       "quantity for product {product} must be a whole number",
+	"checkout.modifier_duplicate":       "modifier id {modifier_id} selected more than once",
+	"checkout.modifier_not_available":   "modifier id {modifier_id} is not available for product {product}",
+	"checkout.modifier_min_select":      "modifier {group} for product {product} requires at least {min} choice(s)",
+	"checkout.modifier_max_select":      "modifier {group} for product {product} allows at most {max} choice(s)",
+	"scale_barcode.not_ean13":           "barcode {barcode} is not EAN-13",
+	"scale_barcode.invalid_prefix":      "barcode {barcode} is not a scale barcode (prefix 20-29)",
+	"scale_barcode.invalid_check_digit": "invalid check digit in barcode {barcode}",
+	"scale_barcode.zero_value":          "weight/price on scale barcode is 0",
+
+	// stock
+	"stock.insufficient":             "insufficient stock for {product}",
+	"stock.insufficient_available":   "insufficient stock for {product} ({available} left)",
+	"stock.quantity_zero":            "quantity must not be 0",
+	"stock.invalid_reason":           "invalid reason \"{reason}\", use one of: {allowed}",
+	"stock.reason_requires_negative": "reason \"{reason}\" only decreases stock, quantity must be negative",
+	"stock.reason_requires_positive": "reason \"{reason}\" only increases stock, quantity must be positive",
+	"stock_take.not_found":           "stock take not found",
+	"stock_take.already_posted":      "stock take is already posted",
+	"stock_take.no_items":            "stock take has no counted items",
+	"stock_take.posted_by_required":  "posted_by is required",
+	"stock_take.counted_negative":    "counted_stock for product id {product_id} must not be negative",
+	"stock_take.counted_precision":   "counted_stock for {product} allows at most {precision} decimal places",
+	"stock_take.reason_required":     "a discrepancy reason is required for product {product}",
+
+	// customers & price lists
+	"customer.not_found":             "customer not found",
+	"customer.name_required":         "customer name is required",
+	"customer.deleted":               "Customer deleted successfully",
+	"customer_group.not_found":       "customer group not found",
+	"customer_group.name_required":   "customer group name is required",
+	"customer_group.name_taken":      "customer group name is already used",
+	"customer_group.deleted":         "Customer group deleted successfully",
+	"price_list.not_found":           "price list not found",
+	"price_list.name_required":       "price list name is required",
+	"price_list.name_taken":          "price list name is already used",
+	"price_list.invalid_period":      "valid_until must be after valid_from",
+	"price_list.inactive":            "price list {name} is not active",
+	"price_list.duplicate_item":      "product id {product_id} is duplicated in the price list",
+	"price_list.item_price_negative": "price for product id {product_id} must not be negative",
+	"price_list.deleted":             "Price list deleted successfully",
+
+	// purchasing
+	"supplier.not_found":                           "supplier not found",
+	"supplier.name_required":                       "supplier name is required",
+	"supplier.in_use":                              "supplier is used by purchase orders and cannot be deleted",
+	"supplier.deleted":                             "Supplier deleted successfully",
+	"purchase_order.not_found":                     "purchase order not found",
+	"purchase_order.supplier_required":             "supplier_id is required",
+	"purchase_order.quantity_ordered_not_positive": "quantity_ordered for product id {product_id} must be greater than 0",
+	"purchase_order.unit_cost_negative":            "unit_cost for product id {product_id} must not be negative",
+	"purchase_order.product_not_ordered":           "product id {product_id} is not in the purchase order",
+	"purchase_order.receive_exceeds_remaining":     "product id {product_id}: received {quantity} {unit} exceeds the remaining {remaining} {unit}",
+	"purchase_order.cannot_receive":                "purchase order with status {status} cannot be received",
+	"purchase_order.cannot_cancel":                 "purchase order with status {status} cannot be cancelled",
+	"purchase_order.cancelled":                     "Purchase order cancelled successfully",
+}
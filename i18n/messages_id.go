@@ -0,0 +1,176 @@
+package i18n
+
+var messagesID = map[string]string{
+	// request & server
+	"request.invalid_body":       "Body request tidak valid",
+	"request.body_too_large":     "Body request melebihi batas {limit} byte",
+	"request.invalid_param":      "Parameter {param} tidak valid",
+	"request.method_not_allowed": "Method tidak didukung",
+	"request.route_not_found":    "Endpoint tidak ditemukan",
+	"request.timeout":            "Permintaan melewati batas waktu",
+	"internal_error":             "Terjadi kesalahan pada server",
+
+	// health check
+	"health.database_unreachable":  "database tidak bisa dihubungi",
+	"health.ping_failed":           "ping database gagal",
+	"health.migrations_unreadable": "gagal membaca schema_migrations",
+	"health.migrations_pending":    "ada migrasi yang belum dijalankan",
+
+	// validasi umum
+	"validation.product_id_required":   "product_id wajib diisi",
+	"validation.items_empty":           "items tidak boleh kosong",
+	"validation.price_negative":        "harga tidak boleh negatif",
+	"validation.quantity_not_positive": "quantity product id {product_id} harus lebih dari 0",
+	"validation.quantity_precision":    "quantity {product} maksimal {precision} digit desimal",
+	"validation.product_duplicate":     "product id {product_id} muncul lebih dari sekali",
+
+	// produk
+	"product.not_found":                  "produk tidak ditemukan",
+	"product.id_not_found":               "produk id {product_id} tidak ditemukan",
+	"product.plu_not_found":              "produk dengan plu {plu} tidak ditemukan",
+	"product.price_stock_negative":       "price dan stock tidak boleh negatif",
+	"product.min_stock_negative":         "min_stock dan reorder_qty tidak boleh negatif",
+	"product.invalid_precision":          "quantity_precision harus antara 0 dan {max}",
+	"product.stock_precision":            "stock maksimal {precision} digit desimal",
+	"product.invalid_plu":                "plu harus 5 digit angka",
+	"product.plu_taken":                  "plu sudah dipakai produk lain",
+	"product.weighted_has_variants":      "produk yang punya varian tidak bisa jadi produk timbang",
+	"product.sku_taken":                  "sku sudah dipakai produk lain",
+	"product.barcode_taken":              "barcode sudah dipakai produk lain",
+	"product.archived":                   "Produk berhasil diarsipkan",
+	"product.restored":                   "Produk berhasil dikeluarkan dari arsip",
+	"product_filter.invalid_sort":        "sort harus name, price atau stock",
+	"product_filter.invalid_order":       "order harus asc atau desc",
+	"product_filter.invalid_price_range": "min_price tidak boleh lebih besar dari max_price",
+	"product_filter.negative_paging":     "limit dan offset tidak boleh negatif",
+	"search.query_too_short":             "q minimal {min} karakter",
+
+	// harga grosir, riwayat & jadwal harga
+	"price_tier.min_quantity_not_positive": "min_quantity harga grosir harus lebih dari 0",
+	"price_tier.min_quantity_precision":    "min_quantity harga grosir maksimal {precision} digit desimal",
+	"price_tier.duplicate":                 "min_quantity {min_quantity} duplikat",
+	"price_tier.invalid_price":             "harga grosir min {min_quantity} harus antara 0 dan harga normal",
+	"price_schedule.not_found":             "jadwal harga tidak ditemukan",
+	"price_schedule.not_pending":           "jadwal harga sudah {status}",
+	"price_schedule.effective_at_required": "effective_at wajib diisi",
+	"price_schedule.effective_at_past":     "effective_at harus di masa depan",
+	"price_schedule.cancelled":             "Jadwal harga berhasil dibatalkan",
+
+	// kategori
+	"category.not_found":           "kategori tidak ditemukan",
+	"category.parent_not_found":    "parent kategori tidak ditemukan",
+	"category.parent_cycle":        "parent_id tidak boleh kategori itu sendiri atau turunannya",
+	"category.target_not_found":    "kategori tujuan tidak ditemukan",
+	"category.target_archived":     "kategori tujuan sudah diarsipkan",
+	"category.target_required":     "target_id wajib diisi untuk mode reassign",
+	"category.target_is_self":      "target_id tidak boleh sama dengan kategori yang dihapus",
+	"category.invalid_delete_mode": "mode harus reject, reassign atau uncategorize",
+	"category.in_use":              "kategori masih dipakai {count} produk",
+	"category.archived":            "Kategori berhasil diarsipkan",
+	"category.restored":            "Kategori berhasil dikeluarkan dari arsip",
+
+	// varian, satuan, modifier, paket
+	"variant.not_found":             "varian tidak ditemukan",
+	"variant.not_found_for_product": "varian id {variant_id} tidak ditemukan untuk produk id {product_id}",
+	"variant.name_required":         "nama varian wajib diisi",
+	"variant.sku_required":          "sku wajib diisi",
+	"variant.sku_taken":             "sku sudah dipakai varian lain",
+	"variant.weighted_product":      "produk timbang tidak bisa punya varian",
+	"variant.bundle_product":        "produk paket tidak bisa punya varian",
+	"variant.bundle_component":      "produk yang dipakai sebagai komponen paket tidak bisa punya varian",
+	"variant.in_use":                "varian sudah punya riwayat transaksi atau stok, tidak bisa dihapus",
+	"variant.deleted":               "Varian berhasil dihapus",
+
+	"unit.not_found":                 "satuan tidak ditemukan",
+	"unit.not_found_for_product":     "satuan \"{unit}\" tidak ditemukan untuk produk id {product_id}",
+	"unit.name_required":             "nama satuan wajib diisi",
+	"unit.name_taken":                "nama satuan sudah ada untuk produk ini",
+	"unit.invalid_conversion_factor": "conversion_factor harus lebih dari 1",
+	"unit.deleted":                   "Satuan berhasil dihapus",
+
+	"modifier_group.not_found":           "modifier group tidak ditemukan",
+	"modifier_group.name_required":       "nama modifier group wajib diisi",
+	"modifier_group.options_empty":       "options tidak boleh kosong",
+	"modifier_group.select_negative":     "min_select dan max_select tidak boleh negatif",
+	"modifier_group.min_exceeds_max":     "min_select tidak boleh lebih besar dari max_select",
+	"modifier_group.min_exceeds_options": "min_select {min} melebihi jumlah opsi",
+	"modifier_group.deleted":             "Modifier group berhasil dihapus",
+	"modifier_option.not_found":          "opsi modifier id {option_id} tidak ditemukan di group ini",
+	"modifier_option.name_required":      "nama opsi modifier wajib diisi",
+	"modifier_option.price_negative":     "harga opsi {option} tidak boleh negatif",
+
+	"bundle.contains_itself":                 "paket tidak bisa berisi dirinya sendiri",
+	"bundle.product_has_variants":            "produk yang punya varian tidak bisa dijadikan paket",
+	"bundle.product_is_component":            "produk ini dipakai sebagai komponen paket lain, tidak bisa dijadikan paket",
+	"bundle.component_product_required":      "product_id komponen wajib diisi",
+	"bundle.component_quantity_not_positive": "quantity komponen product id {product_id} harus lebih dari 0",
+	"bundle.component_has_variants":          "product id {product_id} punya varian, tidak bisa dijadikan komponen paket",
+	"bundle.component_archived":              "product id {product_id} sudah diarsipkan dan tidak bisa jadi komponen paket",
+	"bundle.component_is_bundle":             "product id {product_id} adalah paket, paket tidak bisa berisi paket",
+	"bundle.components_updated":              "Komponen paket berhasil diperbarui",
+
+	// checkout & barcode timbangan
+	"checkout.component_archived":       "paket {bundle} tidak bisa dijual karena komponen {product} sudah diarsipkan",
+	"checkout.product_archived":         "produk {product} sudah diarsipkan dan tidak bisa dijual",
+	"checkout.variant_required":         "produk {product} punya varian, variant_id wajib diisi",
+	"checkout.variant_weighted":         "produk timbang {product} tidak bisa dijual sebagai varian",
+	"checkout.unit_not_supported":       "satuan tidak didukung untuk produk timbang, varian atau paket {product}",
+	"checkout.not_weighted":             "produk {product} bukan produk timbang",
+	"checkout.product_id_with_barcode":  "kirim product_id atau barcode, tidak keduanya (product_id {product_id}, barcode {barcode})",
+	"checkout.quantity_not_whole":       "quantity produk {product} harus bilangan bulat",
+	"checkout.modifier_duplicate":       "modifier id {modifier_id} dipilih lebih dari sekali",
+	"checkout.modifier_not_available":   "modifier id {modifier_id} tidak tersedia untuk produk {product}",
+	"checkout.modifier_min_select":      "modifier {group} untuk produk {product} minimal {min} pilihan",
+	"checkout.modifier_max_select":      "modifier {group} untuk produk {product} maksimal {max} pilihan",
+	"scale_barcode.not_ean13":           "barcode {barcode} bukan EAN-13",
+	"scale_barcode.invalid_prefix":      "barcode {barcode} bukan barcode timbangan (prefix 20-29)",
+	"scale_barcode.invalid_check_digit": "check digit barcode {barcode} tidak valid",
+	"scale_barcode.zero_value":          "berat/harga pada barcode timbangan 0",
+
+	// stok
+	"stock.insufficient":             "stok {product} tidak mencukupi",
+	"stock.insufficient_available":   "stok {product} tidak mencukupi (sisa {available})",
+	"stock.quantity_zero":            "quantity tidak boleh 0",
+	"stock.invalid_reason":           "alasan \"{reason}\" tidak valid, gunakan salah satu: {allowed}",
+	"stock.reason_requires_negative": "alasan \"{reason}\" hanya mengurangi stok, quantity harus negatif",
+	"stock.reason_requires_positive": "alasan \"{reason}\" hanya menambah stok, quantity harus positif",
+	"stock_take.not_found":           "stock opname tidak ditemukan",
+	"stock_take.already_posted":      "stock opname sudah diposting",
+	"stock_take.no_items":            "stock opname belum memiliki hasil hitung",
+	"stock_take.posted_by_required":  "posted_by wajib diisi",
+	"stock_take.counted_negative":    "counted_stock produk id {product_id} tidak boleh negatif",
+	"stock_take.counted_precision":   "counted_stock {product} maksimal {precision} digit desimal",
+	"stock_take.reason_required":     "alasan selisih untuk produk {product} wajib diisi",
+
+	// pelanggan & daftar harga
+	"customer.not_found":             "pelanggan tidak ditemukan",
+	"customer.name_required":         "nama pelanggan wajib diisi",
+	"customer.deleted":               "Pelanggan berhasil dihapus",
+	"customer_group.not_found":       "grup pelanggan tidak ditemukan",
+	"customer_group.name_required":   "nama grup pelanggan wajib diisi",
+	"customer_group.name_taken":      "nama grup pelanggan sudah dipakai",
+	"customer_group.deleted":         "Grup pelanggan berhasil dihapus",
+	"price_list.not_found":           "daftar harga tidak ditemukan",
+	"price_list.name_required":       "nama daftar harga wajib diisi",
+	"price_list.name_taken":          "nama daftar harga sudah dipakai",
+	"price_list.invalid_period":      "valid_until harus setelah valid_from",
+	"price_list.inactive":            "daftar harga {name} tidak berlaku saat ini",
+	"price_list.duplicate_item":      "product id {product_id} duplikat di daftar harga",
+	"price_list.item_price_negative": "harga product id {product_id} tidak boleh negatif",
+	"price_list.deleted":             "Daftar harga berhasil dihapus",
+
+	// pembelian
+	"supplier.not_found":                           "supplier tidak ditemukan",
+	"supplier.name_required":                       "nama supplier wajib diisi",
+	"supplier.in_use":                              "supplier masih dipakai purchase order, tidak bisa dihapus",
+	"supplier.deleted":                             "Supplier berhasil dihapus",
+	"purchase_order.not_found":                     "purchase order tidak ditemukan",
+	"purchase_order.supplier_required":             "supplier_id wajib diisi",
+	"purchase_order.quantity_ordered_not_positive": "quantity_ordered product id {product_id} harus lebih dari 0",
+	"purchase_order.unit_cost_negative":            "unit_cost product id {product_id} tidak boleh negatif",
+	"purchase_order.product_not_ordered":           "product id {product_id} tidak ada di purchase order",
+	"purchase_order.receive_exceeds_remaining":     "product id {product_id}: jumlah diterima {quantity} {unit} melebihi sisa pesanan {remaining} {unit}",
+	"purchase_order.cannot_receive":                "purchase order berstatus {status} tidak bisa diterima",
+	"purchase_order.cannot_cancel":                 "purchase order berstatus {status} tidak bisa dibatalkan",
+	"purchase_order.cancelled":                     "Purchase order berhasil dibatalkan",
+}
@@ -0,0 +1,58 @@
+// Package logging - log terstruktur (log/slog, JSON) dengan request ID dari context
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type ctxKey struct{}
+
+// WithRequestID - simpan request ID di context, ikut tercatat di setiap log *Context
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// RequestID - request ID dari context, kosong di luar request (mis. job background)
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// ParseLevel - debug, info, warn, error (sudah divalidasi config), selain itu info
+func ParseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// Setup - jadikan logger JSON sebagai default slog dan package log, dengan level minimal level
+func Setup(w io.Writer, level string) *slog.Logger {
+	logger := slog.New(contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{Level: ParseLevel(level)})})
+	slog.SetDefault(logger)
+	return logger
+}
+
+// contextHandler - tambahkan request_id dari context ke setiap record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
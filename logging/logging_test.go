@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+		"":      slog.LevelInfo,
+		"trace": slog.LevelInfo,
+	}
+	for in, want := range tests {
+		if got := ParseLevel(in); got != want {
+			t.Errorf("ParseLevel(%q) = %v, ingin %v", in, got, want)
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	if id := RequestID(context.Background()); id != "" {
+		t.Errorf("RequestID di luar request = %q, ingin kosong", id)
+	}
+	if id := RequestID(WithRequestID(context.Background(), "abc-1")); id != "abc-1" {
+		t.Errorf("RequestID = %q, ingin abc-1", id)
+	}
+}
+
+func TestContextHandlerAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	// WithAttrs / WithGroup tetap membawa request_id
+	logger := slog.New(contextHandler{slog.NewJSONHandler(&buf, nil)}).With("job", "checkout")
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-9"), "stok menipis", "product_id", 7)
+	logger.Info("tanpa request")
+
+	dec := json.NewDecoder(&buf)
+	var withID, withoutID map[string]any
+	if err := dec.Decode(&withID); err != nil {
+		t.Fatal(err)
+	}
+	if err := dec.Decode(&withoutID); err != nil {
+		t.Fatal(err)
+	}
+	if withID["request_id"] != "req-9" || withID["job"] != "checkout" || withID["product_id"] != float64(7) {
+		t.Errorf("log = %v", withID)
+	}
+	if _, ok := withoutID["request_id"]; ok {
+		t.Errorf("request_id tidak boleh ada tanpa context request: %v", withoutID)
+	}
+}
@@ -1,48 +1,48 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"errors"
+	"kasir-api/config"
 	"kasir-api/database"
 	"kasir-api/handlers"
+	"kasir-api/logging"
+	"kasir-api/metrics"
 	"kasir-api/repositories"
 	"kasir-api/services"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
-	"strings"
-
-	"github.com/spf13/viper"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
-type Config struct {
-	Port   string `mapstructure:"PORT"`
-	DBConn string `mapstructure:"DB_CONN"`
+// legacyAPI - alias /api/produk, /api/categories, /api/checkout, /api/report/hari-ini untuk client lama
+var legacyAPI = handlers.Deprecation{
+	Since:  time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	Sunset: time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
 }
 
 func main() {
-
-	viper.AutomaticEnv()
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
-	if _, err := os.Stat(".env"); err == nil {
-		viper.SetConfigFile(".env")
-		_ = viper.ReadInConfig()
-	}
-
-	config := Config{
-		Port:   viper.GetString("PORT"),
-		DBConn: viper.GetString("DB_CONN"),
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("konfigurasi tidak valid:\n%v", err)
 	}
-
-	fmt.Println("PORT: ", config.Port)
+	logging.Setup(os.Stdout, cfg.LogLevel)
+	slog.Info("konfigurasi", "config", cfg)
 
 	// Setup database
-	db, err := database.InitDB(config.DBConn)
+	db, err := database.InitDB(cfg.DBConn, cfg.DBPool)
 	if err != nil {
-		log.Fatal("Failed to initialize database:", err)
+		slog.Error("Failed to initialize database", "err", err)
+		os.Exit(1)
 	}
-	defer db.Close()
+
+	// ctx dibatalkan saat SIGINT/SIGTERM, dipakai untuk menghentikan server dan job background
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	productRepo := repositories.NewProductRepository(db)
 	productService := services.NewProductService(productRepo)
@@ -52,32 +52,258 @@ func main() {
 	categorytService := services.NewCategoryService(categoryRepo)
 	categoryHandler := handlers.NewCategoryHandler(categorytService)
 
+	reg := metrics.NewRegistry(db)
+	reg.MustRegister(metrics.NewCountCollector("kasir_low_stock_products",
+		"Jumlah produk aktif dengan stok <= min_stock.", productService.CountLowStock, cfg.RequestTimeout))
+
+	router := handlers.NewRouter()
+	router.Use(
+		handlers.RequestID,
+		handlers.AccessLog,
+		handlers.Metrics(reg),
+		handlers.Recover,
+		handlers.CORS(cfg.CORSOrigins),
+		handlers.MaxBodySize(cfg.MaxBodyBytes),
+	)
+	api := router.Group("/api/v1", handlers.RouteTimeouts{
+		Default: cfg.RequestTimeout,
+		Routes:  cfg.RouteTimeouts,
+	})
+
 	// Setup routes
-	http.HandleFunc("/api/produk", productHandler.HandleProducts)
-	http.HandleFunc("/api/produk/", productHandler.HandleProductByID)
+	api.Handle("GET /produk", productHandler.GetAll)
+	api.Handle("POST /produk", productHandler.Create)
+	api.Handle("GET /produk/low-stock", productHandler.GetLowStock)
+	api.Handle("GET /produk/search", productHandler.Search)
+	api.Handle("GET /produk/{id}", productHandler.GetByID)
+	api.Handle("PUT /produk/{id}", productHandler.Update)
+	api.Handle("DELETE /produk/{id}", productHandler.Delete)
+	api.Handle("POST /produk/{id}/restore", productHandler.Restore)
+	api.Handle("GET /produk/{id}/price-history", productHandler.GetPriceTimeline)
+	api.Handle("POST /produk/{id}/price-schedules", productHandler.SchedulePrice)
+	api.Handle("DELETE /produk/{id}/price-schedules/{schedule_id}", productHandler.CancelPriceSchedule)
+
+	// harga terjadwal dicek tiap menit, jadwal yang lewat saat server mati diterapkan saat start
+	schedulerDone := make(chan struct{})
+	if cfg.FeaturePriceScheduler {
+		go func() {
+			defer close(schedulerDone)
+			productService.RunPriceScheduler(ctx, time.Minute)
+		}()
+	} else {
+		close(schedulerDone)
+	}
+
+	variantRepo := repositories.NewVariantRepository(db)
+	variantService := services.NewVariantService(variantRepo)
+	variantHandler := handlers.NewVariantHandler(variantService)
 
-	http.HandleFunc("/api/categories", categoryHandler.HandleCategories)
-	http.HandleFunc("/api/categories/", categoryHandler.HandleCategoryByID)
+	api.Handle("GET /variants", variantHandler.GetByProduct)
+	api.Handle("POST /variants", variantHandler.Create)
+	api.Handle("GET /variants/{id}", variantHandler.GetByID)
+	api.Handle("PUT /variants/{id}", variantHandler.Update)
+	api.Handle("DELETE /variants/{id}", variantHandler.Delete)
+
+	modifierRepo := repositories.NewModifierRepository(db)
+	modifierService := services.NewModifierService(modifierRepo)
+	modifierHandler := handlers.NewModifierHandler(modifierService)
+
+	api.Handle("GET /modifier-groups", modifierHandler.GetAll)
+	api.Handle("POST /modifier-groups", modifierHandler.Create)
+	api.Handle("GET /modifier-groups/{id}", modifierHandler.GetByID)
+	api.Handle("PUT /modifier-groups/{id}", modifierHandler.Update)
+	api.Handle("DELETE /modifier-groups/{id}", modifierHandler.Delete)
+
+	bundleRepo := repositories.NewBundleRepository(db)
+	bundleService := services.NewBundleService(bundleRepo)
+	bundleHandler := handlers.NewBundleHandler(bundleService)
+
+	api.Handle("GET /bundles", bundleHandler.GetAll)
+	api.Handle("PUT /bundles/{product_id}", bundleHandler.SetComponents)
+
+	unitRepo := repositories.NewUnitRepository(db)
+	unitService := services.NewUnitService(unitRepo)
+	unitHandler := handlers.NewUnitHandler(unitService)
+
+	api.Handle("GET /units", unitHandler.GetByProduct)
+	api.Handle("POST /units", unitHandler.Create)
+	api.Handle("GET /units/{id}", unitHandler.GetByID)
+	api.Handle("PUT /units/{id}", unitHandler.Update)
+	api.Handle("DELETE /units/{id}", unitHandler.Delete)
+
+	api.Handle("GET /categories", categoryHandler.GetAll)
+	api.Handle("POST /categories", categoryHandler.Create)
+	api.Handle("GET /categories/tree", categoryHandler.GetTree)
+	api.Handle("GET /categories/{id}", categoryHandler.GetByID)
+	api.Handle("PUT /categories/{id}", categoryHandler.Update)
+	api.Handle("DELETE /categories/{id}", categoryHandler.Delete)
+	api.Handle("POST /categories/{id}/restore", categoryHandler.Restore)
+
+	priceListRepo := repositories.NewPriceListRepository(db)
+	priceListService := services.NewPriceListService(priceListRepo)
+	priceListHandler := handlers.NewPriceListHandler(priceListService)
+
+	api.Handle("GET /price-lists", priceListHandler.GetAll)
+	api.Handle("POST /price-lists", priceListHandler.Create)
+	api.Handle("GET /price-lists/{id}", priceListHandler.GetByID)
+	api.Handle("PUT /price-lists/{id}", priceListHandler.Update)
+	api.Handle("DELETE /price-lists/{id}", priceListHandler.Delete)
+
+	customerRepo := repositories.NewCustomerRepository(db)
+	customerService := services.NewCustomerService(customerRepo)
+	customerHandler := handlers.NewCustomerHandler(customerService)
+
+	api.Handle("GET /customers", customerHandler.GetAll)
+	api.Handle("POST /customers", customerHandler.Create)
+	api.Handle("GET /customers/{id}", customerHandler.GetByID)
+	api.Handle("PUT /customers/{id}", customerHandler.Update)
+	api.Handle("DELETE /customers/{id}", customerHandler.Delete)
+	api.Handle("GET /customer-groups", customerHandler.GetAllGroups)
+	api.Handle("POST /customer-groups", customerHandler.CreateGroup)
+	api.Handle("GET /customer-groups/{id}", customerHandler.GetGroupByID)
+	api.Handle("PUT /customer-groups/{id}", customerHandler.UpdateGroup)
+	api.Handle("DELETE /customer-groups/{id}", customerHandler.DeleteGroup)
 
 	transactionRepo := repositories.NewTransactionRepository(db)
 	transactionService := services.NewTransactionService(transactionRepo)
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
 
-	http.HandleFunc("/api/checkout", transactionHandler.Checkout)
-	http.HandleFunc("/api/report/hari-ini", transactionHandler.SummaryToday)
+	scaleConfig := services.DefaultScaleBarcodeConfig()
+	if len(cfg.ScalePricePrefixes) > 0 {
+		scaleConfig.PricePrefixes = cfg.ScalePricePrefixes
+	}
+	transactionService.SetScaleBarcodeConfig(scaleConfig)
+	transactionService.SetLocation(cfg.Location)
+	transactionService.OnCheckout(services.NewCheckoutMetrics(reg))
 
-	// localhost:8080/health
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]string{
-			"status":  "OK",
-			"message": "API Running",
-		})
-	})
-	fmt.Println("Server running di localhost:" + config.Port)
+	if cfg.FeatureLowStockAlerts {
+		transactionService.OnLowStock(services.LogLowStockNotifier)
+		if cfg.LowStockWebhookURL != "" {
+			transactionService.OnLowStock(services.NewWebhookLowStockNotifier(cfg.LowStockWebhookURL))
+		}
+	}
 
-	err = http.ListenAndServe(":"+config.Port, nil)
-	if err != nil {
-		fmt.Println("gagal running server")
+	api.Handle("POST /checkout", transactionHandler.Checkout)
+	api.Handle("GET /report/hari-ini", transactionHandler.SummaryToday)
+
+	// path lama sebelum /api/v1, dihapus setelah Sunset (lihat readme)
+	api.Alias("/api", legacyAPI,
+		"GET /produk", "POST /produk", "GET /produk/{id}", "PUT /produk/{id}", "DELETE /produk/{id}",
+		"GET /categories", "POST /categories", "GET /categories/{id}", "PUT /categories/{id}", "DELETE /categories/{id}",
+		"POST /checkout", "GET /report/hari-ini")
+
+	stockRepo := repositories.NewStockRepository(db)
+	stockService := services.NewStockService(stockRepo)
+	stockHandler := handlers.NewStockHandler(stockService)
+
+	api.Handle("POST /stock/adjustments", stockHandler.Adjust)
+	api.Handle("GET /stock/movements", stockHandler.Movements)
+	api.Handle("GET /stock-opname", stockHandler.GetAllStockTakes)
+	api.Handle("POST /stock-opname", stockHandler.CreateStockTake)
+	api.Handle("GET /stock-opname/{id}", stockHandler.GetStockTakeByID)
+	api.Handle("POST /stock-opname/{id}/items", stockHandler.SubmitCounts)
+	api.Handle("POST /stock-opname/{id}/post", stockHandler.PostStockTake)
+
+	supplierRepo := repositories.NewSupplierRepository(db)
+	supplierService := services.NewSupplierService(supplierRepo)
+	supplierHandler := handlers.NewSupplierHandler(supplierService)
+
+	api.Handle("GET /suppliers", supplierHandler.GetAll)
+	api.Handle("POST /suppliers", supplierHandler.Create)
+	api.Handle("GET /suppliers/{id}", supplierHandler.GetByID)
+	api.Handle("PUT /suppliers/{id}", supplierHandler.Update)
+	api.Handle("DELETE /suppliers/{id}", supplierHandler.Delete)
+
+	purchaseOrderRepo := repositories.NewPurchaseOrderRepository(db)
+	purchaseOrderService := services.NewPurchaseOrderService(purchaseOrderRepo)
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(purchaseOrderService)
+
+	api.Handle("GET /purchase-orders", purchaseOrderHandler.GetAll)
+	api.Handle("POST /purchase-orders", purchaseOrderHandler.Create)
+	api.Handle("GET /purchase-orders/{id}", purchaseOrderHandler.GetByID)
+	api.Handle("POST /purchase-orders/{id}/receive", purchaseOrderHandler.Receive)
+	api.Handle("POST /purchase-orders/{id}/cancel", purchaseOrderHandler.Cancel)
+
+	// semua route /api/v1 sudah terdaftar, key ROUTE_TIMEOUTS yang salah ketik ditolak
+	if err := api.CheckTimeouts(); err != nil {
+		log.Fatalf("konfigurasi tidak valid:\n%v", err)
+	}
+
+	healthService := services.NewHealthService(db, cfg.HealthTimeout)
+	healthHandler := handlers.NewHealthHandler(healthService)
+
+	// liveness untuk restart container, readiness untuk load balancer.
+	// /health dipertahankan untuk konfigurasi load balancer lama, sama dengan /health/ready
+	router.Handle("GET /health/live", healthHandler.Live)
+	router.Handle("GET /health/ready", healthHandler.Ready)
+	router.Handle("GET /health", healthHandler.Ready)
+	srv := newServer(":"+cfg.Port, router, cfg)
+
+	// /metrics (termasuk total penjualan) hanya di port admin, tidak lewat load balancer publik
+	var adminSrv *http.Server
+	if cfg.AdminAddr != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("GET /metrics", metrics.Handler(reg))
+		adminSrv = newServer(cfg.AdminAddr, adminMux, cfg)
+	}
+
+	serverErr := make(chan error, 2)
+	go func() {
+		slog.Info("Server running", "addr", srv.Addr)
+		serverErr <- srv.ListenAndServe()
+	}()
+	if adminSrv != nil {
+		go func() {
+			slog.Info("Admin server running", "addr", adminSrv.Addr)
+			serverErr <- adminSrv.ListenAndServe()
+		}()
+	}
+
+	exitCode := 0
+	select {
+	case err := <-serverErr:
+		slog.Error("gagal running server", "err", err)
+		exitCode = 1
+	case <-ctx.Done():
+		slog.Info("shutdown: berhenti menerima request, menunggu request yang berjalan selesai")
+	}
+	// signal berikutnya langsung mematikan proses
+	stop()
+
+	// urutan: request selesai -> job background berhenti -> notifikasi terkirim -> koneksi database ditutup
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Warn("shutdown: masih ada request yang belum selesai", "err", err)
+	}
+	if adminSrv != nil {
+		adminSrv.Shutdown(shutdownCtx)
+	}
+	<-schedulerDone
+	if err := transactionService.Drain(shutdownCtx); err != nil {
+		slog.Warn("shutdown: notifikasi stok menipis belum terkirim semua", "err", err)
+	}
+	if err := db.Close(); err != nil {
+		slog.Error("shutdown: gagal menutup database", "err", err)
+	}
+
+	slog.Info("shutdown selesai")
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// newServer - http.Server dengan batas waktu dan ukuran header dari konfigurasi,
+// dipakai untuk server API dan admin
+func newServer(addr string, h http.Handler, cfg *config.Config) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           h,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
 	}
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"io"
+	"kasir-api/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewServer(t *testing.T) {
+	cfg := &config.Config{
+		ReadHeaderTimeout: 2 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       time.Minute,
+		MaxHeaderBytes:    8192,
+	}
+	srv := newServer(":8080", http.NotFoundHandler(), cfg)
+
+	if srv.Addr != ":8080" || srv.Handler == nil {
+		t.Errorf("Addr = %q, Handler = %v", srv.Addr, srv.Handler)
+	}
+	if srv.ReadHeaderTimeout != cfg.ReadHeaderTimeout || srv.ReadTimeout != cfg.ReadTimeout ||
+		srv.WriteTimeout != cfg.WriteTimeout || srv.IdleTimeout != cfg.IdleTimeout {
+		t.Errorf("timeout server tidak mengikuti konfigurasi: %+v", srv)
+	}
+	if srv.MaxHeaderBytes != cfg.MaxHeaderBytes {
+		t.Errorf("MaxHeaderBytes = %d, ingin %d", srv.MaxHeaderBytes, cfg.MaxHeaderBytes)
+	}
+}
+
+func TestShutdownDrainsInFlightRequest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "checkout selesai")
+	})
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config = newServer("", handler, &config.Config{WriteTimeout: 5 * time.Second})
+	ts.Start()
+	defer ts.Close()
+
+	type result struct {
+		body string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := http.Post(ts.URL+"/api/v1/checkout", "application/json", nil)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		done <- result{string(b), err}
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- ts.Config.Shutdown(context.Background()) }()
+
+	// Shutdown menunggu checkout yang sedang berjalan
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("Shutdown selesai sebelum request selesai: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	if r := <-done; r.err != nil || r.body != "checkout selesai" {
+		t.Fatalf("response = %q, err = %v", r.body, r.err)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Errorf("Shutdown: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package metrics - registry Prometheus untuk endpoint /metrics di port admin
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewRegistry - registry dengan collector standar go_* dan process_*, serta isi connection pool (go_sql_*)
+func NewRegistry(db *sql.DB) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "kasir"),
+	)
+	return reg
+}
+
+// Handler - GET /metrics, metric yang gagal dibaca dilewati tanpa menggagalkan seluruh scrape
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+		ErrorHandling: promhttp.ContinueOnError,
+		Registry:      reg,
+	})
+}
+
+// CountFunc - hitung nilai gauge dari database, mis. jumlah produk stok menipis
+type CountFunc func(ctx context.Context) (int, error)
+
+// countCollector - gauge yang dihitung saat scrape dengan batas waktu
+type countCollector struct {
+	desc    *prometheus.Desc
+	count   CountFunc
+	timeout time.Duration
+}
+
+// NewCountCollector - gauge name yang nilainya dibaca lewat count setiap scrape, maksimal timeout
+func NewCountCollector(name, help string, count CountFunc, timeout time.Duration) prometheus.Collector {
+	return &countCollector{desc: prometheus.NewDesc(name, help, nil, nil), count: count, timeout: timeout}
+}
+
+func (c *countCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *countCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	n, err := c.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(n))
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCountCollector(t *testing.T) {
+	c := NewCountCollector("kasir_low_stock_products", "Jumlah produk aktif dengan stok <= min_stock.",
+		func(ctx context.Context) (int, error) { return 7, nil }, time.Second)
+
+	golden := `
+# HELP kasir_low_stock_products Jumlah produk aktif dengan stok <= min_stock.
+# TYPE kasir_low_stock_products gauge
+kasir_low_stock_products 7
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(golden)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCountCollectorError(t *testing.T) {
+	var deadline bool
+	c := NewCountCollector("kasir_low_stock_products", "Jumlah produk aktif dengan stok <= min_stock.",
+		func(ctx context.Context) (int, error) {
+			_, deadline = ctx.Deadline()
+			return 0, errors.New("database down")
+		}, time.Second)
+
+	// metric yang gagal dihitung dilaporkan sebagai error, bukan nilai 0
+	if err := testutil.CollectAndCompare(c, strings.NewReader("")); err == nil || !strings.Contains(err.Error(), "database down") {
+		t.Errorf("err = %v, want database down", err)
+	}
+	if !deadline {
+		t.Error("count dipanggil tanpa batas waktu")
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+// BundleComponent - isi satu produk paket (mis. Paket Hemat = burger + kentang + minuman)
+type BundleComponent struct {
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	Quantity    int    `json:"quantity"`
+	// Price - harga satuan normal komponen, dipakai sebagai bobot alokasi harga paket
+	Price int `json:"price,omitempty"`
+	// Archived - komponen diarsipkan, paket tidak bisa dijual sampai komponen dipulihkan atau diganti
+	Archived bool `json:"archived,omitempty"`
+}
+
+type BundleRequest struct {
+	Components []BundleComponent `json:"components"`
+}
+
+// BundleAllocation - bagian harga paket yang dialokasikan ke satu komponen pada transaksi
+type BundleAllocation struct {
+	ProductID       int     `json:"product_id"`
+	ProductName     string  `json:"product_name"`
+	Quantity        float64 `json:"quantity"`
+	AllocatedAmount int     `json:"allocated_amount"`
+}
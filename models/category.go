@@ -1,7 +1,31 @@
 package models
 
+import "time"
+
 type Category struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ParentID    *int       `json:"parent_id,omitempty"`
+	Archived    bool       `json:"archived"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	// Children - hanya diisi di endpoint tree
+	Children []Category `json:"children,omitempty"`
+}
+
+// mode hapus kategori yang masih dipakai produk
+const (
+	CategoryDeleteReject       = "reject"
+	CategoryDeleteReassign     = "reassign"
+	CategoryDeleteUncategorize = "uncategorize"
+)
+
+// CategoryDeleteResult - hasil hapus kategori beserta jumlah produk yang dipindahkan
+type CategoryDeleteResult struct {
+	CategoryID       int    `json:"category_id"`
+	Mode             string `json:"mode"`
+	TargetCategoryID *int   `json:"target_category_id,omitempty"`
+	AffectedProducts int    `json:"affected_products"`
+	// ReparentedChildren - sub-kategori yang naik ke parent kategori yang dihapus
+	ReparentedChildren int `json:"reparented_children"`
 }
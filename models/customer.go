@@ -0,0 +1,16 @@
+package models
+
+// CustomerGroup - kelompok pelanggan (mis. "Member", "Reseller"), boleh punya daftar harga sendiri
+type CustomerGroup struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	PriceListID *int   `json:"price_list_id,omitempty"`
+}
+
+type Customer struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Email   string `json:"email"`
+	GroupID *int   `json:"group_id,omitempty"`
+}
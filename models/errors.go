@@ -0,0 +1,73 @@
+package models
+
+import "kasir-api/i18n"
+
+// ErrorCode - kode error yang bisa dibaca mesin, handler memetakannya ke HTTP status
+type ErrorCode string
+
+const (
+	CodeNotFound          ErrorCode = "not_found"
+	CodeConflict          ErrorCode = "conflict"
+	CodeValidation        ErrorCode = "validation_error"
+	CodeInsufficientStock ErrorCode = "insufficient_stock"
+)
+
+// DomainError - error bisnis dari repository/service yang aman ditampilkan ke client.
+// Error lain (database, jaringan) dianggap internal dan tidak diteruskan apa adanya.
+//
+// Key adalah kunci pesan di katalog i18n, Details berisi nilai placeholder pesan
+// sekaligus data tambahan (mis. product_id, sisa stok) untuk front-end.
+type DomainError struct {
+	Code    ErrorCode
+	Key     string
+	Details map[string]any
+}
+
+// Error - pesan dalam bahasa default, untuk log
+func (e *DomainError) Error() string {
+	return e.Message(i18n.Default)
+}
+
+// Message - pesan dalam bahasa yang diminta client
+func (e *DomainError) Message(lang i18n.Lang) string {
+	return i18n.T(lang, e.Key, e.Details)
+}
+
+// With - tambahkan detail yang tidak dipakai di pesan
+func (e *DomainError) With(key string, value any) *DomainError {
+	if e.Details == nil {
+		e.Details = make(map[string]any)
+	}
+	e.Details[key] = value
+	return e
+}
+
+// newDomainError - keyValues berpasangan: nama detail lalu nilainya
+func newDomainError(code ErrorCode, key string, keyValues ...any) *DomainError {
+	e := &DomainError{Code: code, Key: key}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		name, _ := keyValues[i].(string)
+		e.With(name, keyValues[i+1])
+	}
+	return e
+}
+
+// NotFound - data yang diminta / direferensikan tidak ada
+func NotFound(key string, keyValues ...any) *DomainError {
+	return newDomainError(CodeNotFound, key, keyValues...)
+}
+
+// Conflict - bentrok dengan data yang sudah ada (duplikat, status tidak sesuai)
+func Conflict(key string, keyValues ...any) *DomainError {
+	return newDomainError(CodeConflict, key, keyValues...)
+}
+
+// Invalid - input tidak memenuhi aturan validasi
+func Invalid(key string, keyValues ...any) *DomainError {
+	return newDomainError(CodeValidation, key, keyValues...)
+}
+
+// InsufficientStock - stok tidak cukup untuk transaksi / penyesuaian
+func InsufficientStock(key string, keyValues ...any) *DomainError {
+	return newDomainError(CodeInsufficientStock, key, keyValues...)
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/i18n"
+	"testing"
+)
+
+func TestDomainErrorConstructors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *DomainError
+		want ErrorCode
+	}{
+		{name: "not found", err: NotFound("product.not_found"), want: CodeNotFound},
+		{name: "conflict", err: Conflict("category.in_use", "count", 2), want: CodeConflict},
+		{name: "invalid", err: Invalid("search.query_too_short", "min", 2), want: CodeValidation},
+		{name: "stok kurang", err: InsufficientStock("stock.insufficient", "product", "Beras"), want: CodeInsufficientStock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Code != tt.want {
+				t.Errorf("Code = %s, ingin %s", tt.err.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainErrorDetails(t *testing.T) {
+	// key tanpa pasangan nilai diabaikan
+	err := InsufficientStock("stock.insufficient_available", "product", "Beras", "available", 2.5, "sisa")
+	if len(err.Details) != 2 || err.Details["product"] != "Beras" || err.Details["available"] != 2.5 {
+		t.Errorf("Details = %v", err.Details)
+	}
+
+	err.With("product_id", 7)
+	if err.Details["product_id"] != 7 {
+		t.Errorf("With tidak menambah detail: %v", err.Details)
+	}
+	if e := NotFound("product.not_found").With("product_id", 1); e.Details["product_id"] != 1 {
+		t.Errorf("With pada Details nil: %v", e.Details)
+	}
+}
+
+func TestDomainErrorMessage(t *testing.T) {
+	err := InsufficientStock("stock.insufficient", "product", "Beras")
+	if got, want := err.Message(i18n.EN), "insufficient stock for Beras"; got != want {
+		t.Errorf("Message(en) = %q, ingin %q", got, want)
+	}
+	// Error() memakai bahasa default untuk log
+	if got, want := err.Error(), err.Message(i18n.Default); got != want {
+		t.Errorf("Error() = %q, ingin %q", got, want)
+	}
+
+	wrapped := fmt.Errorf("checkout: %w", err)
+	var de *DomainError
+	if !errors.As(wrapped, &de) || de.Key != "stock.insufficient" {
+		t.Errorf("errors.As gagal membuka %v", wrapped)
+	}
+}
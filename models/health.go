@@ -0,0 +1,21 @@
+package models
+
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// HealthReport - hasil GET /health/ready, Status down jika salah satu komponen down
+type HealthReport struct {
+	Status     string                 `json:"status"`
+	Components map[string]HealthCheck `json:"components,omitempty"`
+}
+
+// HealthCheck - status satu komponen (database, migrations, pool) beserta detailnya.
+// Service hanya mengisi Key, Error diisi handler sesuai Accept-Language.
+type HealthCheck struct {
+	Status  string         `json:"status"`
+	Key     string         `json:"key,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// ModifierGroup - kelompok pilihan tambahan untuk produk F&B (mis. "Ukuran Gula", "Add-on")
+type ModifierGroup struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	// MinSelect/MaxSelect - batas jumlah opsi yang dipilih, MaxSelect 0 = tanpa batas
+	MinSelect  int              `json:"min_select"`
+	MaxSelect  int              `json:"max_select"`
+	Options    []ModifierOption `json:"options"`
+	ProductIDs []int            `json:"product_ids,omitempty"`
+}
+
+type ModifierOption struct {
+	ID      int    `json:"id"`
+	GroupID int    `json:"group_id"`
+	Name    string `json:"name"`
+	Price   int    `json:"price"`
+}
+
+// SelectedModifier - modifier yang dipilih pada satu baris transaksi
+type SelectedModifier struct {
+	OptionID  int    `json:"option_id"`
+	GroupName string `json:"group_name"`
+	Name      string `json:"name"`
+	Price     int    `json:"price"`
+}
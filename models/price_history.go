@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+const (
+	PriceSchedulePending   = "pending"
+	PriceScheduleApplied   = "applied"
+	PriceScheduleCancelled = "cancelled"
+)
+
+// PriceChange - satu perubahan harga jual produk
+type PriceChange struct {
+	ID         int       `json:"id"`
+	ProductID  int       `json:"product_id"`
+	OldPrice   *int      `json:"old_price,omitempty"`
+	NewPrice   int       `json:"new_price"`
+	Source     string    `json:"source"`
+	ScheduleID *int      `json:"schedule_id,omitempty"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// PriceSchedule - perubahan harga yang berlaku mulai EffectiveAt
+type PriceSchedule struct {
+	ID          int        `json:"id"`
+	ProductID   int        `json:"product_id"`
+	Price       int        `json:"price"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+// PriceTimeline - harga sekarang, riwayat perubahan (terbaru dulu) dan jadwal yang belum berlaku
+type PriceTimeline struct {
+	ProductID    int             `json:"product_id"`
+	ProductName  string          `json:"product_name"`
+	CurrentPrice int             `json:"current_price"`
+	History      []PriceChange   `json:"history"`
+	Scheduled    []PriceSchedule `json:"scheduled"`
+}
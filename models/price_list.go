@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// PriceList - daftar harga khusus yang menimpa product.price selama masa berlakunya.
+// ValidFrom/ValidUntil kosong = tanpa batas.
+type PriceList struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	// Items - nil saat update = harga tidak diubah, [] = hapus semua harga
+	Items []PriceListItem `json:"items,omitempty"`
+}
+
+// ActiveAt - apakah daftar harga berlaku pada waktu t
+func (pl *PriceList) ActiveAt(t time.Time) bool {
+	if pl.ValidFrom != nil && t.Before(*pl.ValidFrom) {
+		return false
+	}
+	if pl.ValidUntil != nil && !t.Before(*pl.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+type PriceListItem struct {
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	Price       int    `json:"price"`
+}
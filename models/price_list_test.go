@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriceListActiveAt(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		pl   PriceList
+		at   time.Time
+		want bool
+	}{
+		{name: "tanpa periode", pl: PriceList{}, at: from, want: true},
+		{name: "sebelum mulai", pl: PriceList{ValidFrom: &from}, at: from.Add(-time.Second), want: false},
+		{name: "tepat mulai", pl: PriceList{ValidFrom: &from}, at: from, want: true},
+		{name: "di tengah periode", pl: PriceList{ValidFrom: &from, ValidUntil: &until}, at: from.AddDate(0, 0, 10), want: true},
+		// valid_until eksklusif
+		{name: "tepat berakhir", pl: PriceList{ValidFrom: &from, ValidUntil: &until}, at: until, want: false},
+		{name: "sesaat sebelum berakhir", pl: PriceList{ValidUntil: &until}, at: until.Add(-time.Nanosecond), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pl.ActiveAt(tt.at); got != tt.want {
+				t.Errorf("ActiveAt(%v) = %v, ingin %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package models
+
+// PriceTier - harga grosir per satuan dasar jika quantity satu baris >= MinQuantity
+type PriceTier struct {
+	ID          int     `json:"id,omitempty"`
+	MinQuantity float64 `json:"min_quantity"`
+	Price       int     `json:"price"`
+}
@@ -1,10 +1,122 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type Product struct {
-	ID         int       `json:"id"`
-	Name       string    `json:"name"`
-	Price      int       `json:"price"`
-	Stock      int       `json:"stock"`
-	CategoryId *int      `json:"category_id,omitempty"`
-	Category   *Category `json:"category,omitempty"`
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	Price      int     `json:"price"`
+	CostPrice  int     `json:"cost_price"`
+	Stock      float64 `json:"stock"`
+	MinStock   int     `json:"min_stock"`
+	ReorderQty int     `json:"reorder_qty"`
+	BaseUnit   string  `json:"base_unit"`
+	// Weighted - produk timbang, quantity & stok boleh desimal sampai QuantityPrecision digit
+	Weighted          bool              `json:"weighted"`
+	QuantityPrecision int               `json:"quantity_precision"`
+	PLU               string            `json:"plu,omitempty"`
+	SKU               string            `json:"sku,omitempty"`
+	Barcode           string            `json:"barcode,omitempty"`
+	CategoryId        *int              `json:"category_id,omitempty"`
+	Category          *Category         `json:"category,omitempty"`
+	Variants          []ProductVariant  `json:"variants,omitempty"`
+	Modifiers         []ModifierGroup   `json:"modifier_groups,omitempty"`
+	Components        []BundleComponent `json:"components,omitempty"`
+	Units             []ProductUnit     `json:"units,omitempty"`
+	// PriceTiers - nil saat update = tier tidak diubah, [] = hapus semua tier
+	PriceTiers []PriceTier `json:"price_tiers,omitempty"`
+	// Archived - produk diarsipkan: tidak muncul di daftar & tidak bisa dijual, riwayat tetap ada
+	Archived   bool       `json:"archived"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+// ProductUpdate - body PUT /produk/{id}. Field yang tidak dikirim (nil) tidak mengubah nilai lama,
+// supaya client lama tidak menghapus harga, kategori, cost_price dari penerimaan PO, min_stock, plu, sku, dll.
+// Stock yang dikirim dicatat sebagai stock movement (correction), tidak ditimpa diam-diam.
+type ProductUpdate struct {
+	Name              *string     `json:"name"`
+	Price             *int        `json:"price"`
+	BaseUnit          *string     `json:"base_unit"`
+	CategoryId        OptionalID  `json:"category_id"`
+	CostPrice         *int        `json:"cost_price"`
+	Stock             *float64    `json:"stock"`
+	MinStock          *int        `json:"min_stock"`
+	ReorderQty        *int        `json:"reorder_qty"`
+	Weighted          *bool       `json:"weighted"`
+	QuantityPrecision *int        `json:"quantity_precision"`
+	PLU               *string     `json:"plu"`
+	SKU               *string     `json:"sku"`
+	Barcode           *string     `json:"barcode"`
+	PriceTiers        []PriceTier `json:"price_tiers"`
+}
+
+// Apply - timpakan field yang dikirim ke p (stok tidak disentuh, disimpan terpisah oleh repository)
+func (u ProductUpdate) Apply(p *Product) {
+	if u.Name != nil {
+		p.Name = *u.Name
+	}
+	if u.Price != nil {
+		p.Price = *u.Price
+	}
+	if u.BaseUnit != nil {
+		p.BaseUnit = *u.BaseUnit
+	}
+	if u.CategoryId.Set {
+		p.CategoryId = u.CategoryId.Value
+	}
+	if u.PriceTiers != nil {
+		p.PriceTiers = u.PriceTiers
+	}
+	if u.CostPrice != nil {
+		p.CostPrice = *u.CostPrice
+	}
+	if u.MinStock != nil {
+		p.MinStock = *u.MinStock
+	}
+	if u.ReorderQty != nil {
+		p.ReorderQty = *u.ReorderQty
+	}
+	if u.Weighted != nil {
+		p.Weighted = *u.Weighted
+	}
+	if u.QuantityPrecision != nil {
+		p.QuantityPrecision = *u.QuantityPrecision
+	}
+	if u.PLU != nil {
+		p.PLU = *u.PLU
+	}
+	if u.SKU != nil {
+		p.SKU = *u.SKU
+	}
+	if u.Barcode != nil {
+		p.Barcode = *u.Barcode
+	}
+}
+
+// OptionalID - id nullable pada body update: tidak dikirim = tidak diubah (Set false),
+// null = dikosongkan, angka = diganti
+type OptionalID struct {
+	Set   bool
+	Value *int
+}
+
+// UnmarshalJSON - hanya dipanggil jika key ada di body
+func (o *OptionalID) UnmarshalJSON(b []byte) error {
+	o.Set = true
+	return json.Unmarshal(b, &o.Value)
+}
+
+// LowStockAlert - event ketika stok produk (atau varian, jika VariantID terisi) turun sampai/di bawah min_stock
+type LowStockAlert struct {
+	ProductID     int     `json:"product_id"`
+	ProductName   string  `json:"product_name"`
+	VariantID     *int    `json:"variant_id,omitempty"`
+	VariantName   string  `json:"variant_name,omitempty"`
+	Stock         float64 `json:"stock"`
+	MinStock      int     `json:"min_stock"`
+	ReorderQty    int     `json:"reorder_qty"`
+	TransactionID int     `json:"transaction_id,omitempty"`
 }
@@ -0,0 +1,29 @@
+package models
+
+const (
+	DefaultProductPageLimit = 50
+	MaxProductPageLimit     = 200
+)
+
+// ProductFilter - filter, urutan dan halaman untuk daftar produk. Field nil/kosong = tidak difilter.
+type ProductFilter struct {
+	Name string
+	// CategoryID - termasuk semua sub-kategori
+	CategoryID      int
+	MinPrice        *int
+	MaxPrice        *int
+	InStock         bool
+	LowStock        bool
+	IncludeArchived bool
+	// Sort - name, price atau stock. Order - asc atau desc
+	Sort   string
+	Order  string
+	Limit  int
+	Offset int
+}
+
+// ProductPage - satu halaman daftar produk, Total = jumlah semua produk yang cocok dengan filter
+type ProductPage struct {
+	Items []Product `json:"items"`
+	Total int       `json:"total"`
+}
@@ -0,0 +1,21 @@
+package models
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+)
+
+// ProductSearchResult - hasil pencarian kasir, Score makin besar makin relevan
+type ProductSearchResult struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Price        int     `json:"price"`
+	Stock        float64 `json:"stock"`
+	BaseUnit     string  `json:"base_unit"`
+	SKU          string  `json:"sku,omitempty"`
+	Barcode      string  `json:"barcode,omitempty"`
+	PLU          string  `json:"plu,omitempty"`
+	CategoryID   *int    `json:"category_id,omitempty"`
+	CategoryName string  `json:"category_name,omitempty"`
+	Score        float64 `json:"score"`
+}
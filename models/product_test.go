@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestProductUpdateApply(t *testing.T) {
+	category := 3
+	base := Product{
+		Name: "Indomie Goreng", Price: 3500, CostPrice: 2800, MinStock: 10, ReorderQty: 40,
+		BaseUnit: "pcs", CategoryId: &category, PLU: "00012", SKU: "IDM-GR", Barcode: "089686010947",
+		PriceTiers: []PriceTier{{MinQuantity: 40, Price: 3200}},
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want func(p *Product)
+	}{
+		{name: "body kosong tidak mengubah apa pun", body: `{}`, want: func(p *Product) {}},
+		{name: "hanya harga", body: `{"price": 3700}`, want: func(p *Product) { p.Price = 3700 }},
+		{name: "nama dan satuan", body: `{"name": "Indomie Goreng Jumbo", "base_unit": "bks"}`, want: func(p *Product) {
+			p.Name, p.BaseUnit = "Indomie Goreng Jumbo", "bks"
+		}},
+		{name: "kategori null dikosongkan", body: `{"category_id": null}`, want: func(p *Product) { p.CategoryId = nil }},
+		{name: "kategori diganti", body: `{"category_id": 8}`, want: func(p *Product) { v := 8; p.CategoryId = &v }},
+		{name: "nilai nol tetap diterapkan", body: `{"cost_price": 0, "min_stock": 0, "sku": ""}`, want: func(p *Product) {
+			p.CostPrice, p.MinStock, p.SKU = 0, 0, ""
+		}},
+		{name: "tier kosong menghapus", body: `{"price_tiers": []}`, want: func(p *Product) { p.PriceTiers = []PriceTier{} }},
+		{name: "stok tidak disentuh Apply", body: `{"stock": 99}`, want: func(p *Product) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u ProductUpdate
+			if err := json.Unmarshal([]byte(tt.body), &u); err != nil {
+				t.Fatal(err)
+			}
+			got, want := base, base
+			got.PriceTiers = append([]PriceTier(nil), base.PriceTiers...)
+			tt.want(&want)
+			u.Apply(&got)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Apply(%s)\n got %+v\nwant %+v", tt.body, got, want)
+			}
+		})
+	}
+}
+
+func TestOptionalIDUnmarshal(t *testing.T) {
+	tests := []struct {
+		body  string
+		set   bool
+		value *int
+	}{
+		{`{}`, false, nil},
+		{`{"category_id": null}`, true, nil},
+		{`{"category_id": 5}`, true, intPtr(5)},
+	}
+	for _, tt := range tests {
+		var u ProductUpdate
+		if err := json.Unmarshal([]byte(tt.body), &u); err != nil {
+			t.Fatal(err)
+		}
+		if u.CategoryId.Set != tt.set || !reflect.DeepEqual(u.CategoryId.Value, tt.value) {
+			t.Errorf("%s: got set=%v value=%v", tt.body, u.CategoryId.Set, u.CategoryId.Value)
+		}
+	}
+	var u ProductUpdate
+	if err := json.Unmarshal([]byte(`{"category_id": "lima"}`), &u); err == nil {
+		t.Error("category_id bukan angka harus ditolak")
+	}
+}
+
+func intPtr(v int) *int { return &v }
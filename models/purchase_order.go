@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// status purchase order
+const (
+	PurchaseOrderOpen      = "open"
+	PurchaseOrderPartial   = "partial"
+	PurchaseOrderReceived  = "received"
+	PurchaseOrderCancelled = "cancelled"
+)
+
+type PurchaseOrder struct {
+	ID         int                 `json:"id"`
+	SupplierID int                 `json:"supplier_id"`
+	Supplier   *Supplier           `json:"supplier,omitempty"`
+	Status     string              `json:"status"`
+	Note       string              `json:"note"`
+	TotalCost  int                 `json:"total_cost"`
+	CreatedAt  time.Time           `json:"created_at"`
+	Items      []PurchaseOrderItem `json:"items"`
+	Receipts   []GoodsReceipt      `json:"receipts,omitempty"`
+}
+
+// PurchaseOrderItem - quantity dalam satuan dasar produk, boleh desimal untuk produk timbang (kg)
+type PurchaseOrderItem struct {
+	ID               int     `json:"id"`
+	PurchaseOrderID  int     `json:"purchase_order_id"`
+	ProductID        int     `json:"product_id"`
+	ProductName      string  `json:"product_name"`
+	QuantityOrdered  float64 `json:"quantity_ordered"`
+	QuantityReceived float64 `json:"quantity_received"`
+	UnitCost         int     `json:"unit_cost"`
+}
+
+type GoodsReceipt struct {
+	ID              int                `json:"id"`
+	PurchaseOrderID int                `json:"purchase_order_id"`
+	Note            string             `json:"note"`
+	ReceivedAt      time.Time          `json:"received_at"`
+	Items           []GoodsReceiptItem `json:"items"`
+}
+
+type GoodsReceiptItem struct {
+	ProductID int     `json:"product_id"`
+	Quantity  float64 `json:"quantity"`
+	// Unit - satuan quantity & unit_cost (mis. "dus"), kosong = satuan dasar produk
+	Unit         string  `json:"unit,omitempty"`
+	BaseQuantity float64 `json:"base_quantity,omitempty"`
+	// UnitCost opsional, 0 = pakai harga di PO
+	UnitCost int `json:"unit_cost"`
+}
+
+type ReceiveRequest struct {
+	Note  string             `json:"note"`
+	Items []GoodsReceiptItem `json:"items"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "math"
+
+// MaxQuantityPrecision - sesuai kolom NUMERIC(14,3)
+const MaxQuantityPrecision = 3
+
+// RoundQuantity - bulatkan quantity ke sejumlah digit desimal
+func RoundQuantity(q float64, precision int) float64 {
+	p := math.Pow10(precision)
+	return math.Round(q*p) / p
+}
+
+// HasPrecision - true jika q tidak punya digit desimal lebih dari precision
+func HasPrecision(q float64, precision int) bool {
+	return math.Abs(q-RoundQuantity(q, precision)) < 1e-9
+}
+
+// ValidateQuantity - quantity harus sesuai quantity_precision produk (0 = bilangan bulat),
+// aturan yang sama untuk checkout dan pembelian
+func ValidateQuantity(q float64, precision int, productName string) error {
+	if HasPrecision(q, precision) {
+		return nil
+	}
+	if precision == 0 {
+		return Invalid("checkout.quantity_not_whole", "product", productName)
+	}
+	return Invalid("validation.quantity_precision", "product", productName, "precision", precision)
+}
+
+// RoundRupiah - harga x quantity desimal dibulatkan ke rupiah terdekat (half up)
+func RoundRupiah(amount float64) int {
+	return int(math.Floor(amount + 0.5))
+}
@@ -0,0 +1,58 @@
+package models
+
+import "testing"
+
+func TestRoundRupiah(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   int
+	}{
+		{0, 0},
+		{1500, 1500},
+		{1499.4, 1499},
+		{1499.5, 1500},
+		{1499.6, 1500},
+		// 0.375 kg x 12000 / kg
+		{0.375 * 12000, 4500},
+		// 1.234 kg x 8999 / kg = 11104.766
+		{1.234 * 8999, 11105},
+		{0.5, 1},
+		{0.49, 0},
+	}
+	for _, tt := range tests {
+		if got := RoundRupiah(tt.amount); got != tt.want {
+			t.Errorf("RoundRupiah(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestValidateQuantity(t *testing.T) {
+	tests := []struct {
+		name      string
+		q         float64
+		precision int
+		wantKey   string
+	}{
+		{name: "bulat", q: 12, precision: 0},
+		{name: "pecahan di produk biasa", q: 1.5, precision: 0, wantKey: "checkout.quantity_not_whole"},
+		{name: "kg tiga desimal", q: 12.345, precision: 3},
+		{name: "kg lebih dari presisi", q: 1.2345, precision: 3, wantKey: "validation.quantity_precision"},
+		// 0.1 + 0.2 tidak tepat 0.3 di float, tetap lolos presisi 1
+		{name: "galat float", q: 0.1 + 0.2, precision: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuantity(tt.q, tt.precision, "Beras")
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("ValidateQuantity() error = %v", err)
+				}
+				return
+			}
+			de, ok := err.(*DomainError)
+			if !ok || de.Key != tt.wantKey || de.Details["product"] != "Beras" {
+				t.Fatalf("ValidateQuantity() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
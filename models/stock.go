@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+// kode alasan penyesuaian stok
+const (
+	ReasonDamaged    = "damaged"
+	ReasonLost       = "lost"
+	ReasonExpired    = "expired"
+	ReasonFound      = "found"
+	ReasonCorrection = "correction"
+	// dipakai untuk penerimaan barang dari supplier, bukan penyesuaian manual
+	ReasonReceived = "received"
+)
+
+// status sesi stock opname
+const (
+	StockTakeDraft  = "draft"
+	StockTakePosted = "posted"
+)
+
+var AdjustmentReasons = []string{
+	ReasonDamaged,
+	ReasonLost,
+	ReasonExpired,
+	ReasonFound,
+	ReasonCorrection,
+}
+
+func IsValidAdjustmentReason(reason string) bool {
+	for _, r := range AdjustmentReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// adjustmentSigns - arah quantity yang boleh untuk tiap alasan penyesuaian:
+// -1 hanya mengurangi, 1 hanya menambah, 0 dua arah
+var adjustmentSigns = map[string]int{
+	ReasonDamaged:    -1,
+	ReasonLost:       -1,
+	ReasonExpired:    -1,
+	ReasonFound:      1,
+	ReasonCorrection: 0,
+}
+
+// AdjustmentSign - arah quantity yang boleh untuk reason (lihat adjustmentSigns), 0 jika dua arah
+func AdjustmentSign(reason string) int {
+	return adjustmentSigns[reason]
+}
+
+type StockMovement struct {
+	ID            int       `json:"id"`
+	ProductID     int       `json:"product_id"`
+	ProductName   string    `json:"product_name,omitempty"`
+	VariantID     *int      `json:"variant_id,omitempty"`
+	VariantName   string    `json:"variant_name,omitempty"`
+	Quantity      float64   `json:"quantity"`
+	Reason        string    `json:"reason"`
+	ReferenceType string    `json:"reference_type,omitempty"`
+	ReferenceID   *int      `json:"reference_id,omitempty"`
+	Note          string    `json:"note,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StockAdjustmentRequest - VariantID diisi untuk menyesuaikan stok varian, bukan stok produk induk
+type StockAdjustmentRequest struct {
+	ProductID int     `json:"product_id"`
+	VariantID *int    `json:"variant_id,omitempty"`
+	Quantity  float64 `json:"quantity"`
+	Reason    string  `json:"reason"`
+	Note      string  `json:"note"`
+}
+
+type StockTake struct {
+	ID        int             `json:"id"`
+	Status    string          `json:"status"`
+	Note      string          `json:"note"`
+	PostedBy  string          `json:"posted_by,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	PostedAt  *time.Time      `json:"posted_at,omitempty"`
+	Items     []StockTakeItem `json:"items,omitempty"`
+}
+
+type StockTakeItem struct {
+	ID           int     `json:"id"`
+	StockTakeID  int     `json:"stock_take_id"`
+	ProductID    int     `json:"product_id"`
+	ProductName  string  `json:"product_name"`
+	SystemStock  float64 `json:"system_stock"`
+	CountedStock float64 `json:"counted_stock"`
+	Variance     float64 `json:"variance"`
+	Reason       string  `json:"reason,omitempty"`
+}
+
+type StockCountRequest struct {
+	Items []StockCountItem `json:"items"`
+}
+
+type StockCountItem struct {
+	ProductID    int     `json:"product_id"`
+	CountedStock float64 `json:"counted_stock"`
+	Reason       string  `json:"reason"`
+}
+
+type PostStockTakeRequest struct {
+	PostedBy string `json:"posted_by"`
+}
@@ -3,25 +3,51 @@ package models
 type Transaction struct {
 	ID          int                 `json:"id"`
 	TotalAmount int                 `json:"total_amount"`
+	CustomerID  *int                `json:"customer_id,omitempty"`
+	PriceListID *int                `json:"price_list_id,omitempty"`
 	Details     []TransactionDetail `json:"details"`
 }
 
 type TransactionDetail struct {
-	ID            int    `json:"id"`
-	TransactionID int    `json:"transaction_id"`
-	ProductID     int    `json:"product_id"`
-	ProductName   string `json:"product_name"`
-	Quantity      int    `json:"quantity"`
-	Subtotal      int    `json:"subtotal"`
+	ID            int     `json:"id"`
+	TransactionID int     `json:"transaction_id"`
+	ProductID     int     `json:"product_id"`
+	ProductName   string  `json:"product_name"`
+	VariantID     *int    `json:"variant_id,omitempty"`
+	VariantName   string  `json:"variant_name,omitempty"`
+	Quantity      float64 `json:"quantity"`
+	Unit          string  `json:"unit,omitempty"`
+	BaseQuantity  float64 `json:"base_quantity,omitempty"`
+	// UnitPrice - harga satuan yang dipakai (setelah varian/satuan/harga grosir), belum termasuk modifier
+	UnitPrice int `json:"unit_price"`
+	// PriceTier - harga grosir yang berlaku untuk baris ini, kosong jika harga normal
+	PriceTier  *PriceTier         `json:"price_tier,omitempty"`
+	Modifiers  []SelectedModifier `json:"modifiers,omitempty"`
+	Components []BundleAllocation `json:"components,omitempty"`
+	Subtotal   int                `json:"subtotal"`
 }
 
 type CheckoutRequest struct {
 	Items []CheckoutItem `json:"items"`
+	// CustomerID - pelanggan yang dilayani, daftar harga grupnya dipakai jika masih berlaku
+	CustomerID *int `json:"customer_id,omitempty"`
+	// PriceListID - pilih daftar harga secara eksplisit, menimpa daftar harga grup pelanggan
+	PriceListID *int `json:"price_list_id,omitempty"`
 }
 
 type CheckoutItem struct {
-	ProductID int `json:"product_id"`
-	Quantity  int `json:"quantity"`
+	ProductID int     `json:"product_id"`
+	VariantID *int    `json:"variant_id,omitempty"`
+	Quantity  float64 `json:"quantity"`
+	// Barcode - barcode timbangan EAN-13 (prefix 20-29), menggantikan product_id + quantity
+	Barcode string `json:"barcode,omitempty"`
+	// PLU & EmbeddedPrice diisi dari hasil parsing Barcode, bukan dari request
+	PLU           string `json:"-"`
+	EmbeddedPrice int    `json:"-"`
+	// Unit - nama satuan (mis. "pack", "dus"), kosong = satuan dasar produk
+	Unit string `json:"unit,omitempty"`
+	// ModifierIDs - id modifier_options yang dipilih untuk baris ini
+	ModifierIDs []int `json:"modifier_ids,omitempty"`
 }
 
 type SummaryToday struct {
@@ -0,0 +1,12 @@
+package models
+
+// ProductUnit - satuan jual/beli tambahan untuk produk (mis. pack, dus) dengan
+// konversi ke satuan dasar produk
+type ProductUnit struct {
+	ID               int    `json:"id"`
+	ProductID        int    `json:"product_id"`
+	Name             string `json:"name"`
+	ConversionFactor int    `json:"conversion_factor"`
+	// Price - harga jual per satuan, 0 = harga dasar x conversion_factor
+	Price int `json:"price"`
+}
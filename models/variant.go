@@ -0,0 +1,12 @@
+package models
+
+// ProductVariant - varian dari satu produk induk (mis. ukuran S/M/L),
+// masing-masing punya SKU, harga dan stok sendiri
+type ProductVariant struct {
+	ID        int    `json:"id"`
+	ProductID int    `json:"product_id"`
+	Name      string `json:"name"`
+	SKU       string `json:"sku"`
+	Price     int    `json:"price"`
+	Stock     int    `json:"stock"`
+}
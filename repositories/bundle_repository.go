@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"kasir-api/models"
+
+	"github.com/lib/pq"
+)
+
+type BundleRepository struct {
+	db *sql.DB
+}
+
+func NewBundleRepository(db *sql.DB) *BundleRepository {
+	return &BundleRepository{db: db}
+}
+
+// componentsByProduct - komponen paket untuk banyak produk sekaligus, key = id produk paket
+func componentsByProduct(ctx context.Context, q queryer, productIDs []int) (map[int][]models.BundleComponent, error) {
+	out := make(map[int][]models.BundleComponent)
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+        SELECT bc.bundle_product_id, bc.component_product_id, p.name, bc.quantity, p.price, p.archived
+        FROM bundle_components bc
+        JOIN product p ON p.id = bc.component_product_id
+        WHERE bc.bundle_product_id = ANY($1)
+        ORDER BY bc.bundle_product_id, bc.component_product_id
+    `, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bundleID int
+		var c models.BundleComponent
+		if err := rows.Scan(&bundleID, &c.ProductID, &c.ProductName, &c.Quantity, &c.Price, &c.Archived); err != nil {
+			return nil, err
+		}
+		out[bundleID] = append(out[bundleID], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// allocateBundlePrice - bagi total harga paket ke komponen secara proporsional terhadap
+// harga normalnya. Sisa pembulatan diberikan ke komponen terakhir supaya jumlahnya pas.
+func allocateBundlePrice(total int, components []models.BundleComponent) []int {
+	out := make([]int, len(components))
+	if len(components) == 0 {
+		return out
+	}
+
+	weights := make([]int, len(components))
+	weightSum := 0
+	for i, c := range components {
+		weights[i] = c.Price * c.Quantity
+		weightSum += weights[i]
+	}
+	if weightSum == 0 {
+		// semua komponen harganya 0: bagi rata per unit
+		for i, c := range components {
+			weights[i] = c.Quantity
+			weightSum += c.Quantity
+		}
+	}
+
+	allocated := 0
+	for i := range components {
+		if i == len(components)-1 {
+			out[i] = total - allocated
+			break
+		}
+		out[i] = total * weights[i] / weightSum
+		allocated += out[i]
+	}
+	return out
+}
+
+// GetAll - semua produk paket beserta komponennya
+func (repo *BundleRepository) GetAll(ctx context.Context) ([]models.Product, error) {
+	rows, err := repo.db.QueryContext(ctx, `
+        SELECT p.id, p.name, p.price
+        FROM product p
+        WHERE NOT p.archived
+          AND EXISTS (SELECT 1 FROM bundle_components bc WHERE bc.bundle_product_id = p.id)
+        ORDER BY p.name
+    `)
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([]models.Product, 0)
+	ids := make([]int, 0)
+	for rows.Next() {
+		var p models.Product
+		if err := rows.Scan(&p.ID, &p.Name, &p.Price); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		bundles = append(bundles, p)
+		ids = append(ids, p.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	components, err := componentsByProduct(ctx, repo.db, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bundles {
+		bundles[i].Components = components[bundles[i].ID]
+	}
+	return bundles, nil
+}
+
+// SetComponents - ganti seluruh komponen paket, daftar kosong = produk bukan paket lagi
+func (repo *BundleRepository) SetComponents(ctx context.Context, bundleID int, components []models.BundleComponent) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var hasVariants bool
+	err = tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM product_variants WHERE product_id = p.id) FROM product p WHERE p.id = $1",
+		bundleID,
+	).Scan(&hasVariants)
+	if err == sql.ErrNoRows {
+		return models.NotFound("product.not_found")
+	}
+	if err != nil {
+		return err
+	}
+	if hasVariants && len(components) > 0 {
+		return models.Invalid("bundle.product_has_variants")
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM bundle_components WHERE bundle_product_id = $1", bundleID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range components {
+		var isBundle, hasVariants, archived bool
+		err := tx.QueryRowContext(ctx, `
+            SELECT
+                EXISTS (SELECT 1 FROM bundle_components WHERE bundle_product_id = p.id),
+                EXISTS (SELECT 1 FROM product_variants WHERE product_id = p.id),
+                p.archived
+            FROM product p WHERE p.id = $1
+        `, c.ProductID).Scan(&isBundle, &hasVariants, &archived)
+		if err == sql.ErrNoRows {
+			return models.NotFound("product.id_not_found", "product_id", c.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		if archived {
+			return models.Invalid("bundle.component_archived", "product_id", c.ProductID)
+		}
+		if isBundle {
+			return models.Invalid("bundle.component_is_bundle", "product_id", c.ProductID)
+		}
+		if hasVariants {
+			return models.Invalid("bundle.component_has_variants", "product_id", c.ProductID)
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO bundle_components (bundle_product_id, component_product_id, quantity) VALUES ($1, $2, $3)",
+			bundleID, c.ProductID, c.Quantity,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// produk ini sendiri tidak boleh sedang dipakai sebagai komponen paket lain
+	if len(components) > 0 {
+		var usedAsComponent bool
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM bundle_components WHERE component_product_id = $1)", bundleID).Scan(&usedAsComponent)
+		if err != nil {
+			return err
+		}
+		if usedAsComponent {
+			return models.Conflict("bundle.product_is_component")
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"kasir-api/models"
+	"slices"
+	"testing"
+)
+
+func TestAllocateBundlePrice(t *testing.T) {
+	c := func(price, quantity int) models.BundleComponent {
+		return models.BundleComponent{Price: price, Quantity: quantity}
+	}
+
+	tests := []struct {
+		name       string
+		total      int
+		components []models.BundleComponent
+		want       []int
+	}{
+		{name: "tanpa komponen", total: 10000, components: nil, want: []int{}},
+		{name: "satu komponen dapat semua", total: 12500, components: []models.BundleComponent{c(3000, 2)}, want: []int{12500}},
+		{name: "proporsional pas", total: 10000, components: []models.BundleComponent{c(3000, 1), c(2000, 1)}, want: []int{6000, 4000}},
+		{name: "bobot ikut quantity", total: 10000, components: []models.BundleComponent{c(1000, 2), c(1000, 1)}, want: []int{6666, 3334}},
+		{name: "sisa pembulatan ke komponen terakhir", total: 100, components: []models.BundleComponent{c(1, 1), c(1, 1), c(1, 1)}, want: []int{33, 33, 34}},
+		{name: "harga 0 dibagi per unit", total: 10, components: []models.BundleComponent{c(0, 1), c(0, 2)}, want: []int{3, 7}},
+		{name: "total 0", total: 0, components: []models.BundleComponent{c(5000, 1), c(2500, 1)}, want: []int{0, 0}},
+		{name: "paket lebih mahal dari komponen", total: 10001, components: []models.BundleComponent{c(2000, 1), c(2000, 1)}, want: []int{5000, 5001}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allocateBundlePrice(tt.total, tt.components)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("allocateBundlePrice(%d) = %v, want %v", tt.total, got, tt.want)
+			}
+			sum := 0
+			for _, v := range got {
+				sum += v
+			}
+			if len(tt.components) > 0 && sum != tt.total {
+				t.Errorf("jumlah alokasi %d, want %d", sum, tt.total)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"kasir-api/models"
+
+	"github.com/lib/pq"
 )
 
 type CategoryRepository struct {
@@ -14,9 +17,14 @@ func NewCategoryRepository(db *sql.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-func (repo *CategoryRepository) GetAll() ([]models.Category, error) {
-	query := "SELECT id, name, description FROM category"
-	rows, err := repo.db.Query(query)
+// GetAll - daftar kategori, kategori arsip hanya jika includeArchived
+func (repo *CategoryRepository) GetAll(ctx context.Context, includeArchived bool) ([]models.Category, error) {
+	query := "SELECT id, name, description, parent_id, archived, archived_at FROM category"
+	if !includeArchived {
+		query += " WHERE NOT archived"
+	}
+	query += " ORDER BY name"
+	rows, err := repo.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -25,44 +33,122 @@ func (repo *CategoryRepository) GetAll() ([]models.Category, error) {
 	categories := make([]models.Category, 0)
 	for rows.Next() {
 		var p models.Category
-		err := rows.Scan(&p.ID, &p.Name, &p.Description)
+		var parentID sql.NullInt64
+		var archivedAt sql.NullTime
+		err := rows.Scan(&p.ID, &p.Name, &p.Description, &parentID, &p.Archived, &archivedAt)
 		if err != nil {
 			return nil, err
 		}
+		p.ParentID = scanNullableID(parentID)
+		if archivedAt.Valid {
+			p.ArchivedAt = &archivedAt.Time
+		}
 		categories = append(categories, p)
 	}
 
 	return categories, nil
 }
 
-func (repo *CategoryRepository) Create(category *models.Category) error {
-	query := "INSERT INTO category (name, description) VALUES ($1, $2) RETURNING id"
-	err := repo.db.QueryRow(query, category.Name, category.Description).Scan(&category.ID)
-	return err
+func (repo *CategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	query := "INSERT INTO category (name, description, parent_id) VALUES ($1, $2, $3) RETURNING id"
+	err := repo.db.QueryRowContext(ctx, query, category.Name, category.Description, nullableID(category.ParentID)).Scan(&category.ID)
+	return mapCategoryError(err)
+}
+
+// GetTree - semua kategori sebagai pohon, urut nama di tiap level.
+// Kategori yang parent-nya tidak ikut dimuat (mis. parent diarsipkan) ditaruh di root.
+func (repo *CategoryRepository) GetTree(ctx context.Context, includeArchived bool) ([]models.Category, error) {
+	all, err := repo.GetAll(ctx, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(all), nil
+}
+
+// buildCategoryTree - susun daftar datar jadi pohon, urutan anak mengikuti urutan all
+func buildCategoryTree(all []models.Category) []models.Category {
+	byParent := make(map[int][]models.Category)
+	loaded := make(map[int]bool, len(all))
+	for _, c := range all {
+		loaded[c.ID] = true
+	}
+	roots := make([]models.Category, 0)
+	for _, c := range all {
+		if c.ParentID != nil && loaded[*c.ParentID] {
+			byParent[*c.ParentID] = append(byParent[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var build func(nodes []models.Category) []models.Category
+	build = func(nodes []models.Category) []models.Category {
+		for i := range nodes {
+			nodes[i].Children = build(byParent[nodes[i].ID])
+		}
+		return nodes
+	}
+	return build(roots)
 }
 
 // GetByID - ambil category by ID
-func (repo *CategoryRepository) GetByID(id int) (*models.Category, error) {
-	query := "SELECT id, name, description FROM category WHERE id = $1"
+func (repo *CategoryRepository) GetByID(ctx context.Context, id int) (*models.Category, error) {
+	query := "SELECT id, name, description, parent_id, archived, archived_at FROM category WHERE id = $1"
 
 	var p models.Category
-	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Description)
+	var parentID sql.NullInt64
+	var archivedAt sql.NullTime
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(&p.ID, &p.Name, &p.Description, &parentID, &p.Archived, &archivedAt)
 	if err == sql.ErrNoRows {
-		return nil, errors.New("category tidak ditemukan")
+		return nil, models.NotFound("category.not_found")
 	}
 	if err != nil {
 		return nil, err
 	}
+	p.ParentID = scanNullableID(parentID)
+	if archivedAt.Valid {
+		p.ArchivedAt = &archivedAt.Time
+	}
 
 	return &p, nil
 }
 
-func (repo *CategoryRepository) Update(category *models.Category) error {
-	query := "UPDATE category SET name = $1, description = $2 WHERE id = $3"
-	result, err := repo.db.Exec(query, category.Name, category.Description, category.ID)
+// Update - parent baru tidak boleh kategori itu sendiri atau turunannya (mencegah siklus)
+func (repo *CategoryRepository) Update(ctx context.Context, category *models.Category) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
+
+	// perubahan struktur pohon diproses satu per satu supaya dua update tidak bisa membentuk siklus
+	if _, err := tx.ExecContext(ctx, "LOCK TABLE category IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return err
+	}
+
+	if category.ParentID != nil {
+		var cycle bool
+		err := tx.QueryRowContext(ctx, `
+            WITH RECURSIVE ancestors AS (
+                SELECT id, parent_id FROM category WHERE id = $1
+                UNION
+                SELECT c.id, c.parent_id FROM category c JOIN ancestors a ON c.id = a.parent_id
+            )
+            SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
+        `, *category.ParentID, category.ID).Scan(&cycle)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return models.Invalid("category.parent_cycle")
+		}
+	}
+
+	query := "UPDATE category SET name = $1, description = $2, parent_id = $3 WHERE id = $4"
+	result, err := tx.ExecContext(ctx, query, category.Name, category.Description, nullableID(category.ParentID), category.ID)
+	if err != nil {
+		return mapCategoryError(err)
+	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
@@ -70,26 +156,111 @@ func (repo *CategoryRepository) Update(category *models.Category) error {
 	}
 
 	if rows == 0 {
-		return errors.New("category tidak ditemukan")
+		return models.NotFound("category.not_found")
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (repo *CategoryRepository) Delete(id int) error {
-	query := "DELETE FROM category WHERE id = $1"
-	result, err := repo.db.Exec(query, id)
+func mapCategoryError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return models.NotFound("category.parent_not_found")
+	}
+	return err
+}
+
+// Delete - arsipkan kategori. Produk yang masih memakai kategori ini ditangani sesuai mode:
+// reject = tolak, reassign = pindah ke targetID, uncategorize = category_id dikosongkan.
+func (repo *CategoryRepository) Delete(ctx context.Context, id int, mode string, targetID *int) (*models.CategoryDeleteResult, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE menahan produk baru masuk ke kategori ini sampai commit
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT TRUE FROM category WHERE id = $1 FOR UPDATE", id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("category.not_found")
 	}
-	rows, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if rows == 0 {
-		return errors.New("category tidak ditemukan")
+	var count int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM product WHERE category_id = $1", id).Scan(&count)
+	if err != nil {
+		return nil, err
 	}
 
-	return err
+	result := &models.CategoryDeleteResult{CategoryID: id, Mode: mode}
+	switch mode {
+	case models.CategoryDeleteReject:
+		if count > 0 {
+			return nil, models.Conflict("category.in_use", "count", count)
+		}
+	case models.CategoryDeleteReassign:
+		var archived bool
+		err := tx.QueryRowContext(ctx, "SELECT archived FROM category WHERE id = $1 FOR SHARE", *targetID).Scan(&archived)
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("category.target_not_found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if archived {
+			return nil, models.Conflict("category.target_archived")
+		}
+		res, err := tx.ExecContext(ctx, "UPDATE product SET category_id = $1 WHERE category_id = $2", *targetID, id)
+		if err != nil {
+			return nil, err
+		}
+		if count, err = affected(res); err != nil {
+			return nil, err
+		}
+		result.TargetCategoryID = targetID
+	case models.CategoryDeleteUncategorize:
+		res, err := tx.ExecContext(ctx, "UPDATE product SET category_id = NULL WHERE category_id = $1", id)
+		if err != nil {
+			return nil, err
+		}
+		if count, err = affected(res); err != nil {
+			return nil, err
+		}
+	}
+	if mode != models.CategoryDeleteReject {
+		result.AffectedProducts = count
+	}
+
+	// sub-kategori naik satu level supaya tidak menggantung di kategori arsip
+	res, err := tx.ExecContext(ctx,
+		"UPDATE category SET parent_id = (SELECT parent_id FROM category WHERE id = $1) WHERE parent_id = $1",
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.ReparentedChildren, err = affected(res); err != nil {
+		return nil, err
+	}
+
+	if err := setArchived(ctx, tx, "category", id, true, "category.not_found"); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func affected(res sql.Result) (int, error) {
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// Restore - keluarkan kategori dari arsip
+func (repo *CategoryRepository) Restore(ctx context.Context, id int) error {
+	return setArchived(ctx, repo.db, "category", id, false, "category.not_found")
 }
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func categoryID(id int) *int { return &id }
+
+// treeString - pohon jadi "id(anak anak)" supaya perbandingan mudah dibaca
+func treeString(nodes []models.Category) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		parts[i] = fmt.Sprint(n.ID)
+		if len(n.Children) > 0 {
+			parts[i] += "(" + treeString(n.Children) + ")"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	tests := []struct {
+		name string
+		all  []models.Category
+		want string
+	}{
+		{name: "kosong", all: nil, want: ""},
+		{
+			name: "bertingkat, urutan mengikuti input",
+			all: []models.Category{
+				{ID: 1},
+				{ID: 2, ParentID: categoryID(1)},
+				{ID: 3, ParentID: categoryID(2)},
+				{ID: 4, ParentID: categoryID(1)},
+				{ID: 5},
+			},
+			want: "1(2(3) 4) 5",
+		},
+		{
+			// parent diarsipkan dan tidak ikut dimuat
+			name: "parent tidak dimuat naik ke root",
+			all:  []models.Category{{ID: 2, ParentID: categoryID(9)}, {ID: 3, ParentID: categoryID(2)}},
+			want: "2(3)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := treeString(buildCategoryTree(tt.all)); got != tt.want {
+				t.Errorf("pohon = %q, ingin %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCategoryTreeEmptyIsArray(t *testing.T) {
+	// response GET /categories/tree harus [] bukan null
+	if tree := buildCategoryTree(nil); tree == nil {
+		t.Error("buildCategoryTree(nil) = nil, ingin slice kosong")
+	}
+}
+
+func TestMapCategoryError(t *testing.T) {
+	err := mapCategoryError(&pq.Error{Code: "23503"})
+	var de *models.DomainError
+	if !errors.As(err, &de) || de.Key != "category.parent_not_found" {
+		t.Fatalf("err = %v, ingin key category.parent_not_found", err)
+	}
+
+	unique := &pq.Error{Code: "23505"}
+	if err := mapCategoryError(unique); err != unique {
+		t.Errorf("error lain harus diteruskan apa adanya, dapat %v", err)
+	}
+}
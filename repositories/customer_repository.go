@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+
+	"github.com/lib/pq"
+)
+
+type CustomerRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerRepository(db *sql.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+// customerPriceListID - daftar harga dari grup pelanggan, nil jika pelanggan tidak punya grup / grupnya tanpa daftar harga
+func customerPriceListID(ctx context.Context, q queryer, customerID int) (*int, error) {
+	var listID sql.NullInt64
+	err := q.QueryRowContext(ctx, `
+        SELECT g.price_list_id
+        FROM customers c
+        LEFT JOIN customer_groups g ON g.id = c.group_id
+        WHERE c.id = $1
+    `, customerID).Scan(&listID)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("customer.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !listID.Valid {
+		return nil, nil
+	}
+	v := int(listID.Int64)
+	return &v, nil
+}
+
+func nullableID(id *int) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+func scanNullableID(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	id := int(v.Int64)
+	return &id
+}
+
+func (repo *CustomerRepository) GetAllGroups(ctx context.Context) ([]models.CustomerGroup, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT id, name, price_list_id FROM customer_groups ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]models.CustomerGroup, 0)
+	for rows.Next() {
+		var g models.CustomerGroup
+		var listID sql.NullInt64
+		if err := rows.Scan(&g.ID, &g.Name, &listID); err != nil {
+			return nil, err
+		}
+		g.PriceListID = scanNullableID(listID)
+		groups = append(groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (repo *CustomerRepository) GetGroupByID(ctx context.Context, id int) (*models.CustomerGroup, error) {
+	var g models.CustomerGroup
+	var listID sql.NullInt64
+	err := repo.db.QueryRowContext(ctx, "SELECT id, name, price_list_id FROM customer_groups WHERE id = $1", id).
+		Scan(&g.ID, &g.Name, &listID)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("customer_group.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	g.PriceListID = scanNullableID(listID)
+	return &g, nil
+}
+
+func (repo *CustomerRepository) CreateGroup(ctx context.Context, g *models.CustomerGroup) error {
+	err := repo.db.QueryRowContext(ctx,
+		"INSERT INTO customer_groups (name, price_list_id) VALUES ($1, $2) RETURNING id",
+		g.Name, nullableID(g.PriceListID),
+	).Scan(&g.ID)
+	return mapCustomerError(err)
+}
+
+func (repo *CustomerRepository) UpdateGroup(ctx context.Context, g *models.CustomerGroup) error {
+	result, err := repo.db.ExecContext(ctx,
+		"UPDATE customer_groups SET name = $1, price_list_id = $2 WHERE id = $3",
+		g.Name, nullableID(g.PriceListID), g.ID,
+	)
+	if err != nil {
+		return mapCustomerError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.NotFound("customer_group.not_found")
+	}
+	return nil
+}
+
+func (repo *CustomerRepository) DeleteGroup(ctx context.Context, id int) error {
+	result, err := repo.db.ExecContext(ctx, "DELETE FROM customer_groups WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.NotFound("customer_group.not_found")
+	}
+	return nil
+}
+
+// GetAll - daftar pelanggan, groupID 0 = semua grup
+func (repo *CustomerRepository) GetAll(ctx context.Context, groupID int) ([]models.Customer, error) {
+	query := "SELECT id, name, phone, email, group_id FROM customers"
+	var args []interface{}
+	if groupID != 0 {
+		query += " WHERE group_id = $1"
+		args = append(args, groupID)
+	}
+	query += " ORDER BY name"
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := make([]models.Customer, 0)
+	for rows.Next() {
+		var c models.Customer
+		var gID sql.NullInt64
+		if err := rows.Scan(&c.ID, &c.Name, &c.Phone, &c.Email, &gID); err != nil {
+			return nil, err
+		}
+		c.GroupID = scanNullableID(gID)
+		customers = append(customers, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+// GetByID - ambil pelanggan by ID
+func (repo *CustomerRepository) GetByID(ctx context.Context, id int) (*models.Customer, error) {
+	var c models.Customer
+	var gID sql.NullInt64
+	err := repo.db.QueryRowContext(ctx, "SELECT id, name, phone, email, group_id FROM customers WHERE id = $1", id).
+		Scan(&c.ID, &c.Name, &c.Phone, &c.Email, &gID)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("customer.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.GroupID = scanNullableID(gID)
+	return &c, nil
+}
+
+func (repo *CustomerRepository) Create(ctx context.Context, c *models.Customer) error {
+	err := repo.db.QueryRowContext(ctx,
+		"INSERT INTO customers (name, phone, email, group_id) VALUES ($1, $2, $3, $4) RETURNING id",
+		c.Name, c.Phone, c.Email, nullableID(c.GroupID),
+	).Scan(&c.ID)
+	return mapCustomerError(err)
+}
+
+func (repo *CustomerRepository) Update(ctx context.Context, c *models.Customer) error {
+	result, err := repo.db.ExecContext(ctx,
+		"UPDATE customers SET name = $1, phone = $2, email = $3, group_id = $4 WHERE id = $5",
+		c.Name, c.Phone, c.Email, nullableID(c.GroupID), c.ID,
+	)
+	if err != nil {
+		return mapCustomerError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.NotFound("customer.not_found")
+	}
+	return nil
+}
+
+func (repo *CustomerRepository) Delete(ctx context.Context, id int) error {
+	result, err := repo.db.ExecContext(ctx, "DELETE FROM customers WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.NotFound("customer.not_found")
+	}
+	return nil
+}
+
+func mapCustomerError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return models.Conflict("customer_group.name_taken")
+		case "23503":
+			if pqErr.Constraint == "customers_group_id_fkey" {
+				return models.NotFound("customer_group.not_found")
+			}
+			return models.NotFound("price_list.not_found")
+		}
+	}
+	return err
+}
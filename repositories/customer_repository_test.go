@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestNullableID(t *testing.T) {
+	if got := nullableID(nil); got != nil {
+		t.Errorf("nullableID(nil) = %v, ingin nil (NULL)", got)
+	}
+	id := 5
+	if got := nullableID(&id); got != 5 {
+		t.Errorf("nullableID(&5) = %v, ingin 5", got)
+	}
+
+	if got := scanNullableID(sql.NullInt64{}); got != nil {
+		t.Errorf("scanNullableID(NULL) = %v, ingin nil", *got)
+	}
+	if got := scanNullableID(sql.NullInt64{Int64: 7, Valid: true}); got == nil || *got != 7 {
+		t.Errorf("scanNullableID(7) = %v, ingin 7", got)
+	}
+}
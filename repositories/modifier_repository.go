@@ -0,0 +1,377 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+
+	"github.com/lib/pq"
+)
+
+type ModifierRepository struct {
+	db *sql.DB
+}
+
+func NewModifierRepository(db *sql.DB) *ModifierRepository {
+	return &ModifierRepository{db: db}
+}
+
+// queryer - *sql.DB dan *sql.Tx, supaya loader bisa dipakai di luar maupun di dalam transaksi
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// loadOptions - isi Options untuk setiap group berdasarkan ID
+func loadOptions(ctx context.Context, q queryer, groups []models.ModifierGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	ids := make([]int, len(groups))
+	index := make(map[int]int, len(groups))
+	for i := range groups {
+		ids[i] = groups[i].ID
+		index[groups[i].ID] = i
+		groups[i].Options = make([]models.ModifierOption, 0)
+	}
+
+	rows, err := q.QueryContext(ctx, `
+        SELECT id, group_id, name, price
+        FROM modifier_options
+        WHERE group_id = ANY($1)
+        ORDER BY group_id, id
+    `, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var o models.ModifierOption
+		if err := rows.Scan(&o.ID, &o.GroupID, &o.Name, &o.Price); err != nil {
+			return err
+		}
+		g := &groups[index[o.GroupID]]
+		g.Options = append(g.Options, o)
+	}
+	return rows.Err()
+}
+
+// modifierGroupsByProduct - modifier group (beserta opsi) untuk banyak produk sekaligus
+func modifierGroupsByProduct(ctx context.Context, q queryer, productIDs []int) (map[int][]models.ModifierGroup, error) {
+	out := make(map[int][]models.ModifierGroup)
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+        SELECT pmg.product_id, g.id, g.name, g.required, g.min_select, g.max_select
+        FROM product_modifier_groups pmg
+        JOIN modifier_groups g ON g.id = pmg.modifier_group_id
+        WHERE pmg.product_id = ANY($1)
+        ORDER BY pmg.product_id, g.id
+    `, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	var productOf []int
+	groups := make([]models.ModifierGroup, 0)
+	for rows.Next() {
+		var productID int
+		var g models.ModifierGroup
+		if err := rows.Scan(&productID, &g.ID, &g.Name, &g.Required, &g.MinSelect, &g.MaxSelect); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		productOf = append(productOf, productID)
+		groups = append(groups, g)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadOptions(ctx, q, groups); err != nil {
+		return nil, err
+	}
+	for i, g := range groups {
+		out[productOf[i]] = append(out[productOf[i]], g)
+	}
+	return out, nil
+}
+
+// resolveModifiers - validasi pilihan modifier untuk satu produk saat checkout
+// dan kembalikan snapshot modifier + total harga tambahan per unit.
+func resolveModifiers(ctx context.Context, tx *sql.Tx, productID int, productName string, optionIDs []int) ([]models.SelectedModifier, int, error) {
+	grouped, err := modifierGroupsByProduct(ctx, tx, []int{productID})
+	if err != nil {
+		return nil, 0, err
+	}
+	return selectModifiers(grouped[productID], productName, optionIDs)
+}
+
+// selectModifiers - cocokkan optionIDs ke modifier group milik produk dan cek min/max pilihan per group,
+// kembalikan modifier terpilih dan total tambahan harganya
+func selectModifiers(groups []models.ModifierGroup, productName string, optionIDs []int) ([]models.SelectedModifier, int, error) {
+	type pick struct {
+		group  *models.ModifierGroup
+		option models.ModifierOption
+	}
+	available := make(map[int]pick)
+	for i := range groups {
+		for _, o := range groups[i].Options {
+			available[o.ID] = pick{group: &groups[i], option: o}
+		}
+	}
+
+	selected := make([]models.SelectedModifier, 0, len(optionIDs))
+	seen := make(map[int]bool, len(optionIDs))
+	counts := make(map[int]int)
+	extra := 0
+	for _, id := range optionIDs {
+		p, ok := available[id]
+		if !ok {
+			return nil, 0, models.Invalid("checkout.modifier_not_available", "modifier_id", id, "product", productName)
+		}
+		if seen[id] {
+			return nil, 0, models.Invalid("checkout.modifier_duplicate", "modifier_id", id)
+		}
+		seen[id] = true
+		counts[p.group.ID]++
+
+		extra += p.option.Price
+		selected = append(selected, models.SelectedModifier{
+			OptionID:  id,
+			GroupName: p.group.Name,
+			Name:      p.option.Name,
+			Price:     p.option.Price,
+		})
+	}
+
+	for _, g := range groups {
+		min := g.MinSelect
+		if g.Required && min < 1 {
+			min = 1
+		}
+		n := counts[g.ID]
+		if n < min {
+			return nil, 0, models.Invalid("checkout.modifier_min_select", "group", g.Name, "product", productName, "min", min)
+		}
+		if g.MaxSelect > 0 && n > g.MaxSelect {
+			return nil, 0, models.Invalid("checkout.modifier_max_select", "group", g.Name, "product", productName, "max", g.MaxSelect)
+		}
+	}
+
+	return selected, extra, nil
+}
+
+func (repo *ModifierRepository) GetAll(ctx context.Context) ([]models.ModifierGroup, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT id, name, required, min_select, max_select FROM modifier_groups ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]models.ModifierGroup, 0)
+	for rows.Next() {
+		var g models.ModifierGroup
+		if err := rows.Scan(&g.ID, &g.Name, &g.Required, &g.MinSelect, &g.MaxSelect); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadOptions(ctx, repo.db, groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetByID - ambil modifier group by ID beserta opsi dan produk yang memakainya
+func (repo *ModifierRepository) GetByID(ctx context.Context, id int) (*models.ModifierGroup, error) {
+	query := "SELECT id, name, required, min_select, max_select FROM modifier_groups WHERE id = $1"
+
+	var g models.ModifierGroup
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(&g.ID, &g.Name, &g.Required, &g.MinSelect, &g.MaxSelect)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("modifier_group.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []models.ModifierGroup{g}
+	if err := loadOptions(ctx, repo.db, groups); err != nil {
+		return nil, err
+	}
+	g = groups[0]
+
+	rows, err := repo.db.QueryContext(ctx, "SELECT product_id FROM product_modifier_groups WHERE modifier_group_id = $1 ORDER BY product_id", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	g.ProductIDs = make([]int, 0)
+	for rows.Next() {
+		var productID int
+		if err := rows.Scan(&productID); err != nil {
+			return nil, err
+		}
+		g.ProductIDs = append(g.ProductIDs, productID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+func (repo *ModifierRepository) Create(ctx context.Context, g *models.ModifierGroup) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO modifier_groups (name, required, min_select, max_select) VALUES ($1, $2, $3, $4) RETURNING id",
+		g.Name, g.Required, g.MinSelect, g.MaxSelect,
+	).Scan(&g.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := saveOptions(ctx, tx, g); err != nil {
+		return err
+	}
+	if err := saveProducts(ctx, tx, g); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update - opsi dengan id diperbarui, tanpa id ditambahkan, yang tidak dikirim dihapus.
+// product_ids menggantikan daftar produk yang memakai group ini.
+func (repo *ModifierRepository) Update(ctx context.Context, g *models.ModifierGroup) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE modifier_groups SET name = $1, required = $2, min_select = $3, max_select = $4 WHERE id = $5",
+		g.Name, g.Required, g.MinSelect, g.MaxSelect, g.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.NotFound("modifier_group.not_found")
+	}
+
+	keep := make([]int, 0, len(g.Options))
+	for _, o := range g.Options {
+		if o.ID != 0 {
+			keep = append(keep, o.ID)
+		}
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM modifier_options WHERE group_id = $1 AND NOT (id = ANY($2))", g.ID, pq.Array(keep))
+	if err != nil {
+		return err
+	}
+
+	if err := saveOptions(ctx, tx, g); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM product_modifier_groups WHERE modifier_group_id = $1", g.ID)
+	if err != nil {
+		return err
+	}
+	if err := saveProducts(ctx, tx, g); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveOptions(ctx context.Context, tx *sql.Tx, g *models.ModifierGroup) error {
+	for i := range g.Options {
+		o := &g.Options[i]
+		o.GroupID = g.ID
+		if o.ID == 0 {
+			err := tx.QueryRowContext(ctx,
+				"INSERT INTO modifier_options (group_id, name, price) VALUES ($1, $2, $3) RETURNING id",
+				g.ID, o.Name, o.Price,
+			).Scan(&o.ID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := tx.ExecContext(ctx,
+			"UPDATE modifier_options SET name = $1, price = $2 WHERE id = $3 AND group_id = $4",
+			o.Name, o.Price, o.ID, g.ID,
+		)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return models.NotFound("modifier_option.not_found", "option_id", o.ID)
+		}
+	}
+	return nil
+}
+
+func saveProducts(ctx context.Context, tx *sql.Tx, g *models.ModifierGroup) error {
+	for _, productID := range g.ProductIDs {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO product_modifier_groups (product_id, modifier_group_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			productID, g.ID,
+		)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return models.NotFound("product.id_not_found", "product_id", productID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *ModifierRepository) Delete(ctx context.Context, id int) error {
+	query := "DELETE FROM modifier_groups WHERE id = $1"
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return models.NotFound("modifier_group.not_found")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestSelectModifiers(t *testing.T) {
+	groups := []models.ModifierGroup{
+		{ID: 1, Name: "Gula", Required: true, MaxSelect: 1, Options: []models.ModifierOption{
+			{ID: 11, Name: "Normal"}, {ID: 12, Name: "Less"},
+		}},
+		{ID: 2, Name: "Topping", MaxSelect: 2, Options: []models.ModifierOption{
+			{ID: 21, Name: "Boba", Price: 5000}, {ID: 22, Name: "Jelly", Price: 4000}, {ID: 23, Name: "Keju", Price: 6000},
+		}},
+		{ID: 3, Name: "Es", MinSelect: 0, Options: []models.ModifierOption{{ID: 31, Name: "Tanpa es"}}},
+	}
+
+	tests := []struct {
+		name      string
+		optionIDs []int
+		wantExtra int
+		wantNames []string
+		wantKey   string
+	}{
+		{name: "wajib saja", optionIDs: []int{11}, wantNames: []string{"Normal"}},
+		{name: "dengan topping", optionIDs: []int{12, 21, 22}, wantExtra: 9000, wantNames: []string{"Less", "Boba", "Jelly"}},
+		{name: "group wajib tidak dipilih", optionIDs: []int{21}, wantKey: "checkout.modifier_min_select"},
+		{name: "tanpa pilihan", wantKey: "checkout.modifier_min_select"},
+		{name: "melebihi max", optionIDs: []int{11, 21, 22, 23}, wantKey: "checkout.modifier_max_select"},
+		{name: "dua pilihan di group max 1", optionIDs: []int{11, 12}, wantKey: "checkout.modifier_max_select"},
+		{name: "opsi produk lain", optionIDs: []int{11, 99}, wantKey: "checkout.modifier_not_available"},
+		{name: "opsi dobel", optionIDs: []int{11, 21, 21}, wantKey: "checkout.modifier_duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, extra, err := selectModifiers(groups, "Es Teh", tt.optionIDs)
+			if tt.wantKey != "" {
+				var de *models.DomainError
+				if !errors.As(err, &de) || de.Key != tt.wantKey {
+					t.Fatalf("selectModifiers() error = %v, ingin key %s", err, tt.wantKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectModifiers() error = %v", err)
+			}
+			if extra != tt.wantExtra {
+				t.Errorf("extra = %d, ingin %d", extra, tt.wantExtra)
+			}
+			if len(selected) != len(tt.wantNames) {
+				t.Fatalf("selected = %+v, ingin %v", selected, tt.wantNames)
+			}
+			for i, name := range tt.wantNames {
+				if selected[i].Name != name {
+					t.Errorf("selected[%d] = %q, ingin %q (urutan sesuai request)", i, selected[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectModifiersMinSelect(t *testing.T) {
+	// min_select 2 tanpa required tetap wajib dua pilihan
+	groups := []models.ModifierGroup{{ID: 1, Name: "Saus", MinSelect: 2, Options: []models.ModifierOption{
+		{ID: 1, Name: "Sambal"}, {ID: 2, Name: "Tomat"}, {ID: 3, Name: "Mayo", Price: 1000},
+	}}}
+	if _, _, err := selectModifiers(groups, "Kentang", []int{1}); err == nil {
+		t.Fatal("1 pilihan untuk min_select 2 harus ditolak")
+	}
+	_, extra, err := selectModifiers(groups, "Kentang", []int{1, 3})
+	if err != nil || extra != 1000 {
+		t.Fatalf("selectModifiers() = %d, %v; ingin 1000, nil", extra, err)
+	}
+	// produk tanpa modifier group
+	selected, extra, err := selectModifiers(nil, "Air", nil)
+	if err != nil || len(selected) != 0 || extra != 0 {
+		t.Fatalf("tanpa group: %v, %d, %v", selected, extra, err)
+	}
+}
@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+	"time"
+)
+
+// recordPriceChange - catat perubahan harga jual di dalam transaksi yang sedang berjalan
+func recordPriceChange(ctx context.Context, tx *sql.Tx, productID int, oldPrice *int, newPrice int, source string, scheduleID *int) error {
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO product_price_history (product_id, old_price, new_price, source, schedule_id) VALUES ($1, $2, $3, $4, $5)",
+		productID, nullableID(oldPrice), newPrice, source, nullableID(scheduleID),
+	)
+	return err
+}
+
+// GetPriceTimeline - harga sekarang, riwayat perubahan dan jadwal harga yang masih pending
+func (repo *ProductRepository) GetPriceTimeline(ctx context.Context, productID int) (*models.PriceTimeline, error) {
+	t := models.PriceTimeline{ProductID: productID}
+	err := repo.db.QueryRowContext(ctx, "SELECT name, price FROM product WHERE id = $1", productID).Scan(&t.ProductName, &t.CurrentPrice)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("product.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := repo.db.QueryContext(ctx, `
+        SELECT id, old_price, new_price, source, schedule_id, changed_at
+        FROM product_price_history
+        WHERE product_id = $1
+        ORDER BY changed_at DESC, id DESC
+    `, productID)
+	if err != nil {
+		return nil, err
+	}
+	t.History = make([]models.PriceChange, 0)
+	for rows.Next() {
+		c := models.PriceChange{ProductID: productID}
+		var oldPrice, scheduleID sql.NullInt64
+		if err := rows.Scan(&c.ID, &oldPrice, &c.NewPrice, &c.Source, &scheduleID, &c.ChangedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.OldPrice = scanNullableID(oldPrice)
+		c.ScheduleID = scanNullableID(scheduleID)
+		t.History = append(t.History, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	t.Scheduled, err = repo.priceSchedules(ctx, productID, models.PriceSchedulePending)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (repo *ProductRepository) priceSchedules(ctx context.Context, productID int, status string) ([]models.PriceSchedule, error) {
+	rows, err := repo.db.QueryContext(ctx, `
+        SELECT id, product_id, price, effective_at, status, created_at, applied_at
+        FROM product_price_schedules
+        WHERE product_id = $1 AND status = $2
+        ORDER BY effective_at, id
+    `, productID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]models.PriceSchedule, 0)
+	for rows.Next() {
+		var s models.PriceSchedule
+		var appliedAt sql.NullTime
+		if err := rows.Scan(&s.ID, &s.ProductID, &s.Price, &s.EffectiveAt, &s.Status, &s.CreatedAt, &appliedAt); err != nil {
+			return nil, err
+		}
+		if appliedAt.Valid {
+			s.AppliedAt = &appliedAt.Time
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (repo *ProductRepository) CreatePriceSchedule(ctx context.Context, s *models.PriceSchedule) error {
+	err := repo.db.QueryRowContext(ctx, `
+        INSERT INTO product_price_schedules (product_id, price, effective_at)
+        SELECT id, $2, $3 FROM product WHERE id = $1
+        RETURNING id, status, created_at
+    `, s.ProductID, s.Price, s.EffectiveAt).Scan(&s.ID, &s.Status, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.NotFound("product.not_found")
+	}
+	return err
+}
+
+// CancelPriceSchedule - batalkan jadwal harga yang belum diterapkan
+func (repo *ProductRepository) CancelPriceSchedule(ctx context.Context, productID, scheduleID int) error {
+	result, err := repo.db.ExecContext(ctx,
+		"UPDATE product_price_schedules SET status = $1 WHERE id = $2 AND product_id = $3 AND status = $4",
+		models.PriceScheduleCancelled, scheduleID, productID, models.PriceSchedulePending,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	var status string
+	err = repo.db.QueryRowContext(ctx,
+		"SELECT status FROM product_price_schedules WHERE id = $1 AND product_id = $2",
+		scheduleID, productID,
+	).Scan(&status)
+	if err == sql.ErrNoRows {
+		return models.NotFound("price_schedule.not_found")
+	}
+	if err != nil {
+		return err
+	}
+	return models.Conflict("price_schedule.not_pending", "status", status)
+}
+
+// ApplyDuePriceSchedules - terapkan semua jadwal harga yang effective_at-nya sudah lewat,
+// urut dari yang paling awal. Dikembalikan jumlah jadwal yang diterapkan.
+func (repo *ProductRepository) ApplyDuePriceSchedules(ctx context.Context, now time.Time) (int, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, product_id, price
+        FROM product_price_schedules
+        WHERE status = $1 AND effective_at <= $2
+        ORDER BY effective_at, id
+        FOR UPDATE SKIP LOCKED
+    `, models.PriceSchedulePending, now)
+	if err != nil {
+		return 0, err
+	}
+	due := make([]models.PriceSchedule, 0)
+	for rows.Next() {
+		var s models.PriceSchedule
+		if err := rows.Scan(&s.ID, &s.ProductID, &s.Price); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		due = append(due, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, s := range due {
+		var oldPrice int
+		err := tx.QueryRowContext(ctx, "SELECT price FROM product WHERE id = $1 FOR UPDATE", s.ProductID).Scan(&oldPrice)
+		if err != nil {
+			return 0, fmt.Errorf("jadwal harga %d: %w", s.ID, err)
+		}
+		if oldPrice != s.Price {
+			if _, err := tx.ExecContext(ctx, "UPDATE product SET price = $1 WHERE id = $2", s.Price, s.ProductID); err != nil {
+				return 0, err
+			}
+			scheduleID := s.ID
+			if err := recordPriceChange(ctx, tx, s.ProductID, &oldPrice, s.Price, "schedule", &scheduleID); err != nil {
+				return 0, err
+			}
+		}
+		_, err = tx.ExecContext(ctx,
+			"UPDATE product_price_schedules SET status = $1, applied_at = $2 WHERE id = $3",
+			models.PriceScheduleApplied, now, s.ID,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(due), nil
+}
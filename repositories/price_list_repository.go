@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+
+	"github.com/lib/pq"
+)
+
+type PriceListRepository struct {
+	db *sql.DB
+}
+
+func NewPriceListRepository(db *sql.DB) *PriceListRepository {
+	return &PriceListRepository{db: db}
+}
+
+// getPriceList - header daftar harga tanpa item
+func getPriceList(ctx context.Context, q queryer, id int) (*models.PriceList, error) {
+	var pl models.PriceList
+	var from, until sql.NullTime
+	err := q.QueryRowContext(ctx, "SELECT id, name, valid_from, valid_until FROM price_lists WHERE id = $1", id).
+		Scan(&pl.ID, &pl.Name, &from, &until)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("price_list.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if from.Valid {
+		pl.ValidFrom = &from.Time
+	}
+	if until.Valid {
+		pl.ValidUntil = &until.Time
+	}
+	return &pl, nil
+}
+
+// priceListItems - harga khusus per daftar harga, key = id price list
+func priceListItems(ctx context.Context, q queryer, priceListIDs []int) (map[int][]models.PriceListItem, error) {
+	out := make(map[int][]models.PriceListItem)
+	if len(priceListIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+        SELECT i.price_list_id, i.product_id, p.name, i.price
+        FROM price_list_items i
+        JOIN product p ON p.id = i.product_id
+        WHERE i.price_list_id = ANY($1)
+        ORDER BY i.price_list_id, p.name
+    `, pq.Array(priceListIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var listID int
+		var it models.PriceListItem
+		if err := rows.Scan(&listID, &it.ProductID, &it.ProductName, &it.Price); err != nil {
+			return nil, err
+		}
+		out[listID] = append(out[listID], it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// priceListPrice - harga khusus produk di daftar harga, ok = false jika produk tidak ditimpa
+func priceListPrice(ctx context.Context, q queryer, priceListID, productID int) (price int, ok bool, err error) {
+	err = q.QueryRowContext(ctx,
+		"SELECT price FROM price_list_items WHERE price_list_id = $1 AND product_id = $2",
+		priceListID, productID,
+	).Scan(&price)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return price, true, nil
+}
+
+func (repo *PriceListRepository) GetAll(ctx context.Context) ([]models.PriceList, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT id, name, valid_from, valid_until FROM price_lists ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]models.PriceList, 0)
+	ids := make([]int, 0)
+	for rows.Next() {
+		var pl models.PriceList
+		var from, until sql.NullTime
+		if err := rows.Scan(&pl.ID, &pl.Name, &from, &until); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if from.Valid {
+			pl.ValidFrom = &from.Time
+		}
+		if until.Valid {
+			pl.ValidUntil = &until.Time
+		}
+		lists = append(lists, pl)
+		ids = append(ids, pl.ID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	items, err := priceListItems(ctx, repo.db, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].Items = items[lists[i].ID]
+	}
+	return lists, nil
+}
+
+// GetByID - ambil daftar harga by ID beserta harga per produk
+func (repo *PriceListRepository) GetByID(ctx context.Context, id int) (*models.PriceList, error) {
+	pl, err := getPriceList(ctx, repo.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := priceListItems(ctx, repo.db, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	pl.Items = items[id]
+	return pl, nil
+}
+
+func (repo *PriceListRepository) Create(ctx context.Context, pl *models.PriceList) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO price_lists (name, valid_from, valid_until) VALUES ($1, $2, $3) RETURNING id",
+		pl.Name, pl.ValidFrom, pl.ValidUntil,
+	).Scan(&pl.ID)
+	if err != nil {
+		return mapPriceListError(err)
+	}
+
+	if err := savePriceListItems(ctx, tx, pl); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update - items menggantikan seluruh harga di daftar ini, items tidak dikirim = harga lama tetap
+func (repo *PriceListRepository) Update(ctx context.Context, pl *models.PriceList) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE price_lists SET name = $1, valid_from = $2, valid_until = $3 WHERE id = $4",
+		pl.Name, pl.ValidFrom, pl.ValidUntil, pl.ID,
+	)
+	if err != nil {
+		return mapPriceListError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.NotFound("price_list.not_found")
+	}
+
+	if pl.Items != nil {
+		_, err = tx.ExecContext(ctx, "DELETE FROM price_list_items WHERE price_list_id = $1", pl.ID)
+		if err != nil {
+			return err
+		}
+		if err := savePriceListItems(ctx, tx, pl); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func savePriceListItems(ctx context.Context, tx *sql.Tx, pl *models.PriceList) error {
+	for _, it := range pl.Items {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO price_list_items (price_list_id, product_id, price) VALUES ($1, $2, $3)",
+			pl.ID, it.ProductID, it.Price,
+		)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return models.NotFound("product.id_not_found", "product_id", it.ProductID)
+		}
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return models.Conflict("price_list.duplicate_item", "product_id", it.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *PriceListRepository) Delete(ctx context.Context, id int) error {
+	result, err := repo.db.ExecContext(ctx, "DELETE FROM price_lists WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return models.NotFound("price_list.not_found")
+	}
+
+	return nil
+}
+
+func mapPriceListError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return models.Conflict("price_list.name_taken")
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+
+	"github.com/lib/pq"
+)
+
+// priceTiersByProduct - harga grosir untuk banyak produk sekaligus, urut min_quantity naik
+func priceTiersByProduct(ctx context.Context, q queryer, productIDs []int) (map[int][]models.PriceTier, error) {
+	out := make(map[int][]models.PriceTier)
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+        SELECT id, product_id, min_quantity, price
+        FROM product_price_tiers
+        WHERE product_id = ANY($1)
+        ORDER BY product_id, min_quantity
+    `, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID int
+		var t models.PriceTier
+		if err := rows.Scan(&t.ID, &productID, &t.MinQuantity, &t.Price); err != nil {
+			return nil, err
+		}
+		out[productID] = append(out[productID], t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// resolvePriceTier - tier dengan min_quantity terbesar yang masih <= baseQuantity, nil jika tidak ada
+func resolvePriceTier(ctx context.Context, q queryer, productID int, baseQuantity float64) (*models.PriceTier, error) {
+	tiers, err := priceTiersByProduct(ctx, q, []int{productID})
+	if err != nil {
+		return nil, err
+	}
+	return selectPriceTier(tiers[productID], baseQuantity), nil
+}
+
+// selectPriceTier - pilih tier untuk quantity, tiers tidak harus urut. quantity dibulatkan ke presisi
+// kolom supaya 0.1+0.2 tetap memenuhi tier min 0.3
+func selectPriceTier(tiers []models.PriceTier, baseQuantity float64) *models.PriceTier {
+	qty := models.RoundQuantity(baseQuantity, models.MaxQuantityPrecision)
+	var best *models.PriceTier
+	for i := range tiers {
+		if tiers[i].MinQuantity <= qty && (best == nil || tiers[i].MinQuantity > best.MinQuantity) {
+			best = &tiers[i]
+		}
+	}
+	return best
+}
+
+// GetPriceTiers - harga grosir satu produk, urut min_quantity naik
+func (repo *ProductRepository) GetPriceTiers(ctx context.Context, productID int) ([]models.PriceTier, error) {
+	tiers, err := priceTiersByProduct(ctx, repo.db, []int{productID})
+	if err != nil {
+		return nil, err
+	}
+	return tiers[productID], nil
+}
+
+// savePriceTiers - ganti seluruh harga grosir produk dalam transaksi yang sedang berjalan
+func savePriceTiers(ctx context.Context, tx *sql.Tx, productID int, tiers []models.PriceTier) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM product_price_tiers WHERE product_id = $1", productID)
+	if err != nil {
+		return err
+	}
+	for i := range tiers {
+		err := tx.QueryRowContext(ctx,
+			"INSERT INTO product_price_tiers (product_id, min_quantity, price) VALUES ($1, $2, $3) RETURNING id",
+			productID, tiers[i].MinQuantity, tiers[i].Price,
+		).Scan(&tiers[i].ID)
+		if err != nil {
+			return fmt.Errorf("simpan harga grosir min %g: %w", tiers[i].MinQuantity, err)
+		}
+	}
+	return nil
+}
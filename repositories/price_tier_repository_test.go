@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"kasir-api/models"
+	"testing"
+)
+
+func TestSelectPriceTier(t *testing.T) {
+	// sengaja tidak urut, seperti tier yang dikirim client
+	tiers := []models.PriceTier{
+		{ID: 2, MinQuantity: 48, Price: 4200},
+		{ID: 1, MinQuantity: 12, Price: 4500},
+		{ID: 3, MinQuantity: 0.3, Price: 4900},
+	}
+
+	tests := []struct {
+		name     string
+		quantity float64
+		wantID   int
+	}{
+		{name: "di bawah semua tier", quantity: 0.25},
+		{name: "tepat batas kecil", quantity: 0.3, wantID: 3},
+		{name: "galat float tetap kena tier", quantity: 0.1 + 0.2, wantID: 3},
+		{name: "di antara tier", quantity: 11, wantID: 3},
+		{name: "tepat 12", quantity: 12, wantID: 1},
+		{name: "di antara 12 dan 48", quantity: 47.999, wantID: 1},
+		{name: "tier terbesar", quantity: 48, wantID: 2},
+		{name: "jauh di atas", quantity: 1000, wantID: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectPriceTier(tiers, tt.quantity)
+			if tt.wantID == 0 {
+				if got != nil {
+					t.Fatalf("selectPriceTier(%v) = %+v, ingin nil", tt.quantity, got)
+				}
+				return
+			}
+			if got == nil || got.ID != tt.wantID {
+				t.Fatalf("selectPriceTier(%v) = %+v, ingin tier id %d", tt.quantity, got, tt.wantID)
+			}
+		})
+	}
+
+	if got := selectPriceTier(nil, 100); got != nil {
+		t.Errorf("tanpa tier = %+v, ingin nil", got)
+	}
+}
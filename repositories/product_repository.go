@@ -1,11 +1,15 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"kasir-api/models"
-	"log"
+	"log/slog"
+	"strings"
+
+	"github.com/lib/pq"
 )
 
 type ProductRepository struct {
@@ -16,93 +20,245 @@ func NewProductRepository(db *sql.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-func (repo *ProductRepository) GetAll() ([]models.Product, error) {
-	query := "SELECT id, name, price, stock, category_id FROM product"
-	rows, err := repo.db.Query(query)
+// productColumns - kolom product (alias p) yang dibaca scanProduct, urutannya harus sama
+const productColumns = `p.id, p.name, p.price, p.cost_price, p.stock, p.min_stock, p.reorder_qty,
+        p.base_unit, p.weighted, p.quantity_precision, COALESCE(p.plu, ''), COALESCE(p.sku, ''),
+        COALESCE(p.barcode, ''), p.category_id, p.archived, p.archived_at`
+
+// productCategoryColumns - kolom kategori hasil LEFT JOIN category c, dibaca scanProductWithCategory
+const productCategoryColumns = "c.id, c.name, c.description"
+
+// rowScanner - *sql.Row atau *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanProduct - baca satu baris productColumns, extra = kolom tambahan setelahnya
+func scanProduct(row rowScanner, extra ...any) (models.Product, error) {
+	var p models.Product
+	var catID sql.NullInt64
+	var archivedAt sql.NullTime
+
+	dest := append([]any{
+		&p.ID, &p.Name, &p.Price, &p.CostPrice, &p.Stock, &p.MinStock, &p.ReorderQty,
+		&p.BaseUnit, &p.Weighted, &p.QuantityPrecision, &p.PLU, &p.SKU,
+		&p.Barcode, &catID, &p.Archived, &archivedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return p, err
+	}
+
+	if archivedAt.Valid {
+		p.ArchivedAt = &archivedAt.Time
+	}
+	if catID.Valid {
+		v := int(catID.Int64)
+		p.CategoryId = &v
+	}
+	return p, nil
+}
+
+// scanProductWithCategory - productColumns + productCategoryColumns, Category hanya diisi jika join menemukan data
+func scanProductWithCategory(row rowScanner) (models.Product, error) {
+	var cID sql.NullInt64
+	var cName, cDesc sql.NullString
+
+	p, err := scanProduct(row, &cID, &cName, &cDesc)
+	if err != nil {
+		return p, err
+	}
+	if cID.Valid {
+		p.Category = &models.Category{
+			ID:          int(cID.Int64),
+			Name:        cName.String,
+			Description: cDesc.String,
+		}
+	}
+	return p, nil
+}
+
+// productSortColumns - kolom yang boleh dipakai untuk sort, dipetakan ke SQL
+var productSortColumns = map[string]string{
+	"name":  "p.name",
+	"price": "p.price",
+	"stock": "p.stock",
+}
+
+// productFilterWhere - klausa WHERE dan argumennya untuk filter daftar produk
+func productFilterWhere(f models.ProductFilter) (string, []interface{}) {
+	var args []interface{}
+	var where []string
+	if f.Name != "" {
+		args = append(args, "%"+likeEscape(f.Name)+"%")
+		where = append(where, fmt.Sprintf("p.name ILIKE $%d", len(args)))
+	}
+	if f.CategoryID != 0 {
+		args = append(args, f.CategoryID)
+		where = append(where, fmt.Sprintf(`p.category_id IN (
+            WITH RECURSIVE sub AS (
+                SELECT id FROM category WHERE id = $%d
+                UNION
+                SELECT c.id FROM category c JOIN sub ON c.parent_id = sub.id
+            )
+            SELECT id FROM sub
+        )`, len(args)))
+	}
+	if f.MinPrice != nil {
+		args = append(args, *f.MinPrice)
+		where = append(where, fmt.Sprintf("p.price >= $%d", len(args)))
+	}
+	if f.MaxPrice != nil {
+		args = append(args, *f.MaxPrice)
+		where = append(where, fmt.Sprintf("p.price <= $%d", len(args)))
+	}
+	if f.InStock {
+		where = append(where, "p.stock > 0")
+	}
+	if f.LowStock {
+		where = append(where, "p.min_stock > 0 AND p.stock <= p.min_stock")
+	}
+	if !f.IncludeArchived {
+		where = append(where, "NOT p.archived")
+	}
+	whereSQL := ""
+	if len(where) > 0 {
+		whereSQL = " WHERE " + strings.Join(where, " AND ")
+	}
+	return whereSQL, args
+}
+
+// GetAllWithCategory - LEFT JOIN untuk isi field Category di model. Filter, urutan dan
+// halaman diterapkan di SQL, Total dihitung dengan filter yang sama tanpa LIMIT/OFFSET.
+func (repo *ProductRepository) GetAllWithCategory(ctx context.Context, f models.ProductFilter) (*models.ProductPage, error) {
+	whereSQL, args := productFilterWhere(f)
+
+	page := &models.ProductPage{}
+	err := repo.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM product p"+whereSQL, args...).Scan(&page.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	sortColumn, ok := productSortColumns[f.Sort]
+	if !ok {
+		sortColumn = "p.name"
+	}
+	order := "ASC"
+	if f.Order == "desc" {
+		order = "DESC"
+	}
+
+	query := `
+        SELECT ` + productColumns + `, ` + productCategoryColumns + `
+        FROM product p
+        LEFT JOIN category c ON c.id = p.category_id
+    ` + whereSQL
+	// p.id sebagai tie-breaker supaya urutan antar halaman stabil
+	args = append(args, f.Limit, f.Offset)
+	query += fmt.Sprintf(" ORDER BY %s %s, p.id %s LIMIT $%d OFFSET $%d", sortColumn, order, order, len(args)-1, len(args))
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	products := make([]models.Product, 0)
+	out := make([]models.Product, 0)
 	for rows.Next() {
-		var p models.Product
-		var catID sql.NullInt64
-		err := rows.Scan(&p.ID, &p.Name, &p.Price, &p.Stock, &catID)
+		p, err := scanProductWithCategory(rows)
 		if err != nil {
 			return nil, err
 		}
-		if catID.Valid {
-			v := int(catID.Int64)
-			p.CategoryId = &v
-		} else {
-			p.CategoryId = nil
-		}
-		products = append(products, p)
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return products, nil
+	if err := repo.attachDetails(ctx, out); err != nil {
+		return nil, err
+	}
+	page.Items = out
+	return page, nil
+}
+
+// attachDetails - isi field Variants, Modifiers, Components (paket), Units dan PriceTiers, yang tidak ada dibiarkan kosong
+func (repo *ProductRepository) attachDetails(ctx context.Context, products []models.Product) error {
+	ids := make([]int, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+
+	variants, err := variantsByProduct(ctx, repo.db, ids)
+	if err != nil {
+		return err
+	}
+	modifiers, err := modifierGroupsByProduct(ctx, repo.db, ids)
+	if err != nil {
+		return err
+	}
+	components, err := componentsByProduct(ctx, repo.db, ids)
+	if err != nil {
+		return err
+	}
+	units, err := unitsByProduct(ctx, repo.db, ids)
+	if err != nil {
+		return err
+	}
+	tiers, err := priceTiersByProduct(ctx, repo.db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range products {
+		products[i].Variants = variants[products[i].ID]
+		products[i].Modifiers = modifiers[products[i].ID]
+		products[i].Components = components[products[i].ID]
+		products[i].Units = units[products[i].ID]
+		products[i].PriceTiers = tiers[products[i].ID]
+	}
+	return nil
 }
 
-// GetAllWithCategory - LEFT JOIN untuk isi field Category di model
-func (repo *ProductRepository) GetAllWithCategory(name string) ([]models.Product, error) {
+// CountLowStock - jumlah produk yang muncul di GetLowStock, untuk metrics
+func (repo *ProductRepository) CountLowStock(ctx context.Context) (int, error) {
+	var count int
+	err := repo.db.QueryRowContext(ctx, `
+        SELECT COUNT(*) FROM product
+        WHERE NOT archived AND min_stock > 0 AND stock <= min_stock
+    `).Scan(&count)
+	return count, err
+}
+
+// GetLowStock - produk dengan stok <= min_stock (hanya yang min_stock-nya diset)
+func (repo *ProductRepository) GetLowStock(ctx context.Context) ([]models.Product, error) {
 	query := `
-        SELECT p.id, p.name, p.price, p.stock,
-               p.category_id,
-               c.id, c.name, c.description
+        SELECT ` + productColumns + `
         FROM product p
-        LEFT JOIN category c ON c.id = p.category_id
+        WHERE NOT p.archived AND p.min_stock > 0 AND p.stock <= p.min_stock
+        ORDER BY p.stock - p.min_stock ASC, p.name ASC
     `
-	fmt.Println("name:", name)
-	var args []interface{}
-	if name != "" {
-		query += " WHERE p.name ILIKE $1"
-		args = append(args, "%"+name+"%")
-	}
-
-	rows, err := repo.db.Query(query, args...)
+	rows, err := repo.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	out := make([]models.Product, 0)
+	products := make([]models.Product, 0)
 	for rows.Next() {
-		var p models.Product
-		var catID sql.NullInt64
-		var cID sql.NullInt64
-		var cName, cDesc sql.NullString
-
-		if err := rows.Scan(
-			&p.ID, &p.Name, &p.Price, &p.Stock,
-			&catID,
-			&cID, &cName, &cDesc,
-		); err != nil {
+		p, err := scanProduct(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		if catID.Valid {
-			v := int(catID.Int64)
-			p.CategoryId = &v
-		}
-		// isi Category hanya jika join menemukan data
-		if cID.Valid {
-			p.Category = &models.Category{
-				ID:          int(cID.Int64),
-				Name:        cName.String,
-				Description: cDesc.String,
-			}
-		}
-		out = append(out, p)
+		products = append(products, p)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return out, nil
+
+	return products, nil
 }
 
-func (repo *ProductRepository) Create(product *models.Product) error {
-	query := "INSERT INTO product (name, price, stock, category_id) VALUES ($1, $2, $3, $4) RETURNING id"
+func (repo *ProductRepository) Create(ctx context.Context, product *models.Product) error {
+	query := "INSERT INTO product (name, price, cost_price, stock, min_stock, reorder_qty, base_unit, weighted, quantity_precision, plu, sku, barcode, category_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), $13) RETURNING id"
 	var catID interface{}
 
 	if product.CategoryId == nil {
@@ -110,82 +266,75 @@ func (repo *ProductRepository) Create(product *models.Product) error {
 	} else {
 		catID = *product.CategoryId
 	}
-	err := repo.db.QueryRow(query, product.Name, product.Price, product.Stock, catID).Scan(&product.ID)
-	return err
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query, product.Name, product.Price, product.CostPrice, product.Stock, product.MinStock, product.ReorderQty, product.BaseUnit, product.Weighted, product.QuantityPrecision, product.PLU, product.SKU, product.Barcode, catID).Scan(&product.ID)
+	if err != nil {
+		return mapProductError(err)
+	}
+
+	if err := savePriceTiers(ctx, tx, product.ID, product.PriceTiers); err != nil {
+		return err
+	}
+	if err := recordPriceChange(ctx, tx, product.ID, nil, product.Price, "create", nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // GetByID - ambil produk by ID
-func (repo *ProductRepository) GetByID(id int) (*models.Product, error) {
-	query := "SELECT id, name, price, stock, category_id FROM product WHERE id = $1"
-
-	var p models.Product
-	var catID sql.NullInt64
+func (repo *ProductRepository) GetByID(ctx context.Context, id int) (*models.Product, error) {
+	query := "SELECT " + productColumns + " FROM product p WHERE p.id = $1"
 
-	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Price, &p.Stock, &catID)
+	p, err := scanProduct(repo.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("produk tidak ditemukan")
+		return nil, models.NotFound("product.not_found")
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	if catID.Valid {
-		v := int(catID.Int64)
-		p.CategoryId = &v
-	}
-
 	return &p, nil
 }
 
-// GetByID - ambil produk by ID + object Category (LEFT JOIN)
-func (repo *ProductRepository) GetByIdWithCategory(id int) (*models.Product, error) {
+// GetByIdWithCategory - ambil produk by ID + object Category (LEFT JOIN)
+func (repo *ProductRepository) GetByIdWithCategory(ctx context.Context, id int) (*models.Product, error) {
 	query := `
-        SELECT 
-            p.id, p.name, p.price, p.stock, p.category_id,
-            c.id, c.name, c.description
+        SELECT ` + productColumns + `, ` + productCategoryColumns + `
         FROM product p
         LEFT JOIN category c ON c.id = p.category_id
         WHERE p.id = $1
     `
 
-	var p models.Product
-	var catID sql.NullInt64
-	var cID sql.NullInt64
-	var cName, cDesc sql.NullString
-
-	err := repo.db.QueryRow(query, id).Scan(
-		&p.ID, &p.Name, &p.Price, &p.Stock, &catID,
-		&cID, &cName, &cDesc,
-	)
+	p, err := scanProductWithCategory(repo.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("produk tidak ditemukan")
+		return nil, models.NotFound("product.not_found")
 	}
 	if err != nil {
 		return nil, err
 	}
-
-	// set CategoryID (nullable)
-	if catID.Valid {
-		v := int(catID.Int64)
-		p.CategoryId = &v
-	} else {
-		log.Println("category_id belum diset")
+	if p.CategoryId == nil {
+		slog.DebugContext(ctx, "category_id belum diset", "product_id", p.ID)
 	}
 
-	// isi object Category hanya jika baris kategori ada (bukan NULL)
-	if cID.Valid {
-		p.Category = &models.Category{
-			ID:          int(cID.Int64),
-			Name:        cName.String,
-			Description: cDesc.String,
-		}
+	withDetails := []models.Product{p}
+	if err := repo.attachDetails(ctx, withDetails); err != nil {
+		return nil, err
 	}
 
-	return &p, nil
+	return &withDetails[0], nil
 }
 
-func (repo *ProductRepository) Update(product *models.Product) error {
-	query := "UPDATE product SET name = $1, price = $2, stock = $3, category_id = $4 WHERE id = $5"
+// Update - simpan field produk. stock nil = stok tidak diubah; jika diisi dan berbeda, selisihnya dicatat
+// sebagai stock movement "correction" supaya riwayat stok tetap lengkap. product.Stock diisi stok terbaru.
+func (repo *ProductRepository) Update(ctx context.Context, product *models.Product, stock *float64) error {
+	query := "UPDATE product SET name = $1, price = $2, cost_price = $3, min_stock = $4, reorder_qty = $5, base_unit = $6, weighted = $7, quantity_precision = $8, plu = NULLIF($9, ''), sku = NULLIF($10, ''), barcode = NULLIF($11, ''), category_id = $12 WHERE id = $13"
 
 	var catID interface{}
 
@@ -195,26 +344,123 @@ func (repo *ProductRepository) Update(product *models.Product) error {
 		catID = *product.CategoryId
 	}
 
-	result, err := repo.db.Exec(query, product.Name, product.Price, product.Stock, catID, product.ID)
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
+	var oldPrice int
+	var oldStock float64
+	err = tx.QueryRowContext(ctx, "SELECT price, stock FROM product WHERE id = $1 FOR UPDATE", product.ID).Scan(&oldPrice, &oldStock)
+	if err == sql.ErrNoRows {
+		return models.NotFound("product.not_found")
+	}
 	if err != nil {
 		return err
 	}
 
-	if rows == 0 {
-		return errors.New("produk tidak ditemukan")
+	if product.Weighted {
+		var hasVariants bool
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM product_variants WHERE product_id = $1)", product.ID).Scan(&hasVariants)
+		if err != nil {
+			return err
+		}
+		if hasVariants {
+			return models.Invalid("product.weighted_has_variants")
+		}
 	}
 
-	return nil
+	_, err = tx.ExecContext(ctx, query, product.Name, product.Price, product.CostPrice, product.MinStock, product.ReorderQty, product.BaseUnit, product.Weighted, product.QuantityPrecision, product.PLU, product.SKU, product.Barcode, catID, product.ID)
+	if err != nil {
+		return mapProductError(err)
+	}
+
+	if oldPrice != product.Price {
+		if err := recordPriceChange(ctx, tx, product.ID, &oldPrice, product.Price, "update", nil); err != nil {
+			return err
+		}
+	}
+
+	product.Stock = oldStock
+	var delta float64
+	if stock != nil {
+		delta = models.RoundQuantity(*stock-oldStock, product.QuantityPrecision)
+	}
+	if delta != 0 {
+		_, err = tx.ExecContext(ctx, "UPDATE product SET stock = stock + $1 WHERE id = $2", delta, product.ID)
+		if err != nil {
+			return err
+		}
+		m := models.StockMovement{
+			ProductID:     product.ID,
+			Quantity:      delta,
+			Reason:        models.ReasonCorrection,
+			ReferenceType: "product_update",
+			ReferenceID:   &product.ID,
+		}
+		if err := insertMovement(ctx, tx, &m); err != nil {
+			return err
+		}
+		product.Stock = *stock
+	}
+
+	// price_tiers tidak dikirim = harga grosir lama tetap dipakai
+	if product.PriceTiers != nil {
+		if err := savePriceTiers(ctx, tx, product.ID, product.PriceTiers); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
-func (repo *ProductRepository) Delete(id int) error {
-	query := "DELETE FROM product WHERE id = $1"
-	result, err := repo.db.Exec(query, id)
+// Delete - arsipkan produk (soft delete), baris produk tetap ada untuk riwayat transaksi
+func (repo *ProductRepository) Delete(ctx context.Context, id int) error {
+	return setArchived(ctx, repo.db, "product", id, true, "product.not_found")
+}
+
+// Restore - keluarkan produk dari arsip
+func (repo *ProductRepository) Restore(ctx context.Context, id int) error {
+	return setArchived(ctx, repo.db, "product", id, false, "product.not_found")
+}
+
+func mapProductError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		switch pqErr.Constraint {
+		case "product_plu_key":
+			return models.Conflict("product.plu_taken")
+		case "product_sku_key":
+			return models.Conflict("product.sku_taken")
+		case "product_barcode_key":
+			return models.Conflict("product.barcode_taken")
+		}
+	}
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return models.NotFound("category.not_found")
+	}
+	return err
+}
+
+// isForeignKeyViolation - baris masih direferensikan (atau mereferensikan) baris lain
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// execer - dipenuhi *sql.DB dan *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// setArchived - ubah status arsip produk/kategori. Mengarsipkan ulang tidak menggeser archived_at.
+func setArchived(ctx context.Context, db execer, table string, id int, archived bool, notFound string) error {
+	query := "UPDATE " + table + " SET archived = FALSE, archived_at = NULL WHERE id = $1"
+	if archived {
+		query = "UPDATE " + table + " SET archived = TRUE, archived_at = COALESCE(archived_at, NOW()) WHERE id = $1"
+	}
+	result, err := db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -224,8 +470,8 @@ func (repo *ProductRepository) Delete(id int) error {
 	}
 
 	if rows == 0 {
-		return errors.New("produk tidak ditemukan")
+		return models.NotFound(notFound)
 	}
 
-	return err
+	return nil
 }
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kasir-api/models"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRow - rowScanner yang mengisi dest seperti database/sql (nilai dikonversi lewat Scan milik tipe tujuan)
+type fakeRow []any
+
+func (r fakeRow) Scan(dest ...any) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("jumlah kolom %d, dest %d", len(r), len(dest))
+	}
+	for i, d := range dest {
+		if s, ok := d.(sql.Scanner); ok {
+			if err := s.Scan(r[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r[i]))
+	}
+	return nil
+}
+
+func productRow(categoryID, archivedAt any) fakeRow {
+	return fakeRow{7, "Beras", 15000, 12000, 2.5, 5, 10, "kg", true, 3, "01234", "BRS-1", "899", categoryID, archivedAt != nil, archivedAt}
+}
+
+func TestProductColumnsMatchScan(t *testing.T) {
+	// jumlah kolom di productColumns harus sama dengan dest di scanProduct, tiap kolom menyebut "p." sekali
+	if got, want := strings.Count(productColumns, "p."), len(productRow(nil, nil)); got != want {
+		t.Fatalf("productColumns berisi %d kolom, scanProduct membaca %d", got, want)
+	}
+}
+
+func TestScanProduct(t *testing.T) {
+	archived := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	p, err := scanProduct(productRow(int64(4), archived))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != 7 || p.Name != "Beras" || p.Stock != 2.5 || !p.Weighted || p.QuantityPrecision != 3 || p.Barcode != "899" {
+		t.Errorf("scanProduct() = %+v", p)
+	}
+	if p.CategoryId == nil || *p.CategoryId != 4 {
+		t.Errorf("CategoryId = %v, ingin 4", p.CategoryId)
+	}
+	if p.ArchivedAt == nil || !p.ArchivedAt.Equal(archived) {
+		t.Errorf("ArchivedAt = %v, ingin %v", p.ArchivedAt, archived)
+	}
+
+	p, err = scanProduct(productRow(nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.CategoryId != nil || p.ArchivedAt != nil {
+		t.Errorf("kolom NULL harus jadi nil, dapat CategoryId=%v ArchivedAt=%v", p.CategoryId, p.ArchivedAt)
+	}
+}
+
+func TestScanProductWithCategory(t *testing.T) {
+	p, err := scanProductWithCategory(append(productRow(int64(4), nil), int64(4), "Sembako", "bahan pokok"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Category == nil || p.Category.ID != 4 || p.Category.Name != "Sembako" || p.Category.Description != "bahan pokok" {
+		t.Errorf("Category = %+v", p.Category)
+	}
+
+	// LEFT JOIN tanpa kategori: semua kolom c.* NULL
+	p, err = scanProductWithCategory(append(productRow(nil, nil), nil, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Category != nil {
+		t.Errorf("Category = %+v, ingin nil", p.Category)
+	}
+}
+
+// fakeExecer - execer yang mencatat query terakhir dan mengembalikan jumlah baris terpengaruh
+type fakeExecer struct {
+	query string
+	args  []any
+	rows  int64
+	err   error
+}
+
+func (e *fakeExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.query, e.args = query, args
+	if e.err != nil {
+		return nil, e.err
+	}
+	return driverResult(e.rows), nil
+}
+
+type driverResult int64
+
+func (r driverResult) LastInsertId() (int64, error) { return 0, nil }
+func (r driverResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+func TestSetArchived(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     string
+		archived  bool
+		wantQuery []string
+	}{
+		// COALESCE: mengarsipkan ulang tidak menggeser archived_at
+		{name: "arsipkan produk", table: "product", archived: true, wantQuery: []string{"UPDATE product ", "archived = TRUE", "COALESCE(archived_at, NOW())"}},
+		{name: "pulihkan kategori", table: "category", archived: false, wantQuery: []string{"UPDATE category ", "archived = FALSE", "archived_at = NULL"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeExecer{rows: 1}
+			if err := setArchived(context.Background(), db, tt.table, 9, tt.archived, "x.not_found"); err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range tt.wantQuery {
+				if !strings.Contains(db.query, w) {
+					t.Errorf("query %q tidak memuat %q", db.query, w)
+				}
+			}
+			if len(db.args) != 1 || db.args[0] != 9 {
+				t.Errorf("args = %v, ingin [9]", db.args)
+			}
+		})
+	}
+}
+
+func TestSetArchivedNotFound(t *testing.T) {
+	err := setArchived(context.Background(), &fakeExecer{}, "category", 9, true, "category.not_found")
+	var de *models.DomainError
+	if !errors.As(err, &de) || de.Code != models.CodeNotFound || de.Key != "category.not_found" {
+		t.Fatalf("err = %v, ingin key category.not_found", err)
+	}
+
+	boom := errors.New("koneksi putus")
+	if err := setArchived(context.Background(), &fakeExecer{err: boom}, "product", 9, false, "product.not_found"); !errors.Is(err, boom) {
+		t.Errorf("err = %v, ingin error exec diteruskan", err)
+	}
+}
+
+func TestLikeEscape(t *testing.T) {
+	tests := map[string]string{
+		"teh botol": "teh botol",
+		"100%":      `100\%`,
+		"a_b":       `a\_b`,
+		`c:\x`:      `c:\\x`,
+		`%_\`:       `\%\_\\`,
+	}
+	for in, want := range tests {
+		if got := likeEscape(in); got != want {
+			t.Errorf("likeEscape(%q) = %q, ingin %q", in, got, want)
+		}
+	}
+}
+
+func TestProductFilterWhere(t *testing.T) {
+	minPrice, maxPrice := 1000, 5000
+
+	where, args := productFilterWhere(models.ProductFilter{IncludeArchived: true})
+	if where != "" || len(args) != 0 {
+		t.Errorf("tanpa filter: where = %q args = %v, ingin kosong", where, args)
+	}
+
+	where, args = productFilterWhere(models.ProductFilter{})
+	if where != " WHERE NOT p.archived" {
+		t.Errorf("default: where = %q, ingin hanya produk aktif", where)
+	}
+
+	where, args = productFilterWhere(models.ProductFilter{
+		Name:       "50%",
+		CategoryID: 3,
+		MinPrice:   &minPrice,
+		MaxPrice:   &maxPrice,
+		InStock:    true,
+		LowStock:   true,
+	})
+	wantArgs := []interface{}{`%50\%%`, 3, 1000, 5000}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, ingin %v", args, wantArgs)
+	}
+	// nomor placeholder mengikuti urutan args
+	for _, w := range []string{"p.name ILIKE $1", "WHERE id = $2", "p.price >= $3", "p.price <= $4", "p.stock > 0", "p.stock <= p.min_stock", "NOT p.archived"} {
+		if !strings.Contains(where, w) {
+			t.Errorf("where tidak memuat %q:\n%s", w, where)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"kasir-api/models"
+	"strings"
+	"unicode"
+)
+
+// prefixTSQuery - "indomie gor" -> "indomie:* & gor:*", kata non alfanumerik dibuang
+func prefixTSQuery(term string) string {
+	words := strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// likeEscape - escape karakter wildcard LIKE dari input kasir
+func likeEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// Search - cari produk aktif berdasarkan nama, SKU (produk & varian), barcode, PLU dan nama kategori.
+// Kandidat dikumpulkan per sumber supaya tiap cabang memakai index-nya sendiri (trigram / full-text /
+// prefix), baru kemudian diberi skor dan diurutkan. Kecocokan persis kode (scan barcode) selalu di atas.
+func (repo *ProductRepository) Search(ctx context.Context, term string, limit int) ([]models.ProductSearchResult, error) {
+	escaped := likeEscape(term)
+	contains := "%" + escaped + "%"
+	prefix := escaped + "%"
+	tsquery := prefixTSQuery(term)
+
+	rows, err := repo.db.QueryContext(ctx, `
+        WITH candidates AS (
+            SELECT id FROM product WHERE name % $1 OR $1 <% name OR name ILIKE $2
+            UNION
+            SELECT id FROM product WHERE $4 <> '' AND to_tsvector('simple', name) @@ to_tsquery('simple', $4)
+            UNION
+            SELECT id FROM product WHERE sku ILIKE $3 OR barcode LIKE $3 OR plu = $1
+            UNION
+            SELECT product_id FROM product_variants WHERE sku ILIKE $3
+            UNION
+            SELECT p.id FROM product p JOIN category c ON c.id = p.category_id
+            WHERE c.name % $1 OR c.name ILIKE $2
+        )
+        SELECT p.id, p.name, p.price, p.stock, p.base_unit,
+               COALESCE(p.sku, ''), COALESCE(p.barcode, ''), COALESCE(p.plu, ''),
+               p.category_id, COALESCE(c.name, ''),
+               (
+                   CASE WHEN p.barcode = $1 OR p.plu = $1 OR LOWER(p.sku) = LOWER($1) THEN 3 ELSE 0 END
+                   + CASE WHEN p.name ILIKE $3 THEN 1 ELSE 0 END
+                   + CASE WHEN p.sku ILIKE $3 OR p.barcode LIKE $3 THEN 0.8 ELSE 0 END
+                   + GREATEST(similarity(p.name, $1), word_similarity($1, p.name))
+                   + CASE WHEN $4 <> '' THEN ts_rank(to_tsvector('simple', p.name), to_tsquery('simple', $4)) ELSE 0 END
+                   + 0.3 * COALESCE(similarity(c.name, $1), 0)
+               )::FLOAT8 AS score
+        FROM candidates k
+        JOIN product p ON p.id = k.id
+        LEFT JOIN category c ON c.id = p.category_id
+        WHERE NOT p.archived
+        ORDER BY score DESC, p.name ASC, p.id ASC
+        LIMIT $5
+    `, term, contains, prefix, tsquery, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]models.ProductSearchResult, 0)
+	for rows.Next() {
+		var r models.ProductSearchResult
+		var catID sql.NullInt64
+		if err := rows.Scan(
+			&r.ID, &r.Name, &r.Price, &r.Stock, &r.BaseUnit,
+			&r.SKU, &r.Barcode, &r.PLU,
+			&catID, &r.CategoryName, &r.Score,
+		); err != nil {
+			return nil, err
+		}
+		r.CategoryID = scanNullableID(catID)
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import "testing"
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		term, want string
+	}{
+		{term: "indomie gor", want: "indomie:* & gor:*"},
+		{term: "  Teh   BOTOL ", want: "teh:* & botol:*"},
+		{term: "aqua 600ml", want: "aqua:* & 600ml:*"},
+		// operator tsquery dari input tidak boleh lolos, to_tsquery akan error
+		{term: "kopi & !susu | (gula):*", want: "kopi:* & susu:* & gula:*"},
+		{term: "it's", want: "it:* & s:*"},
+		{term: "kopi-susu", want: "kopi:* & susu:*"},
+		{term: "kué", want: "kué:*"},
+		// hanya tanda baca: kosong, cabang full-text dilewati ($4 <> '')
+		{term: "%%", want: ""},
+	}
+	for _, tt := range tests {
+		if got := prefixTSQuery(tt.term); got != tt.want {
+			t.Errorf("prefixTSQuery(%q) = %q, ingin %q", tt.term, got, tt.want)
+		}
+	}
+}
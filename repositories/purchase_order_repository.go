@@ -0,0 +1,371 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"kasir-api/models"
+)
+
+type PurchaseOrderRepository struct {
+	db *sql.DB
+}
+
+func NewPurchaseOrderRepository(db *sql.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db: db}
+}
+
+func (repo *PurchaseOrderRepository) Create(ctx context.Context, po *models.PurchaseOrder) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM supplier WHERE id = $1)", po.SupplierID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return models.NotFound("supplier.not_found")
+	}
+
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO purchase_orders (supplier_id, note) VALUES ($1, $2) RETURNING id, status, created_at",
+		po.SupplierID, po.Note,
+	).Scan(&po.ID, &po.Status, &po.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	po.TotalCost = 0
+	for i := range po.Items {
+		item := &po.Items[i]
+		var precision int
+		err := tx.QueryRowContext(ctx, "SELECT name, quantity_precision FROM product WHERE id = $1", item.ProductID).Scan(&item.ProductName, &precision)
+		if err == sql.ErrNoRows {
+			return models.NotFound("product.id_not_found", "product_id", item.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := models.ValidateQuantity(item.QuantityOrdered, precision, item.ProductName); err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+            INSERT INTO purchase_order_items (purchase_order_id, product_id, quantity_ordered, unit_cost)
+            VALUES ($1, $2, $3, $4)
+            RETURNING id
+        `, po.ID, item.ProductID, item.QuantityOrdered, item.UnitCost).Scan(&item.ID)
+		if err != nil {
+			return err
+		}
+		item.PurchaseOrderID = po.ID
+		po.TotalCost += models.RoundRupiah(item.QuantityOrdered * float64(item.UnitCost))
+	}
+
+	return tx.Commit()
+}
+
+// GetAll - daftar PO tanpa item, status kosong = semua
+func (repo *PurchaseOrderRepository) GetAll(ctx context.Context, status string) ([]models.PurchaseOrder, error) {
+	query := `
+        SELECT po.id, po.supplier_id, s.name, po.status, po.note, po.created_at,
+               COALESCE((SELECT SUM(ROUND(i.quantity_ordered * i.unit_cost))::bigint
+                         FROM purchase_order_items i
+                         WHERE i.purchase_order_id = po.id), 0)
+        FROM purchase_orders po
+        JOIN supplier s ON s.id = po.supplier_id
+    `
+	var args []interface{}
+	if status != "" {
+		query += " WHERE po.status = $1"
+		args = append(args, status)
+	}
+	query += " ORDER BY po.created_at DESC, po.id DESC"
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]models.PurchaseOrder, 0)
+	for rows.Next() {
+		var po models.PurchaseOrder
+		var supplierName string
+		if err := rows.Scan(&po.ID, &po.SupplierID, &supplierName, &po.Status, &po.Note, &po.CreatedAt, &po.TotalCost); err != nil {
+			return nil, err
+		}
+		po.Supplier = &models.Supplier{ID: po.SupplierID, Name: supplierName}
+		out = append(out, po)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetByID - PO lengkap dengan item dan riwayat penerimaan barang
+func (repo *PurchaseOrderRepository) GetByID(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	var po models.PurchaseOrder
+	var s models.Supplier
+	err := repo.db.QueryRowContext(ctx, `
+        SELECT po.id, po.status, po.note, po.created_at,
+               s.id, s.name, s.phone, s.email, s.address
+        FROM purchase_orders po
+        JOIN supplier s ON s.id = po.supplier_id
+        WHERE po.id = $1
+    `, id).Scan(&po.ID, &po.Status, &po.Note, &po.CreatedAt, &s.ID, &s.Name, &s.Phone, &s.Email, &s.Address)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("purchase_order.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	po.SupplierID = s.ID
+	po.Supplier = &s
+
+	rows, err := repo.db.QueryContext(ctx, `
+        SELECT i.id, i.purchase_order_id, i.product_id, p.name,
+               i.quantity_ordered, i.quantity_received, i.unit_cost
+        FROM purchase_order_items i
+        JOIN product p ON p.id = i.product_id
+        WHERE i.purchase_order_id = $1
+        ORDER BY i.id
+    `, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	po.Items = make([]models.PurchaseOrderItem, 0)
+	for rows.Next() {
+		var it models.PurchaseOrderItem
+		if err := rows.Scan(
+			&it.ID, &it.PurchaseOrderID, &it.ProductID, &it.ProductName,
+			&it.QuantityOrdered, &it.QuantityReceived, &it.UnitCost,
+		); err != nil {
+			return nil, err
+		}
+		po.TotalCost += models.RoundRupiah(it.QuantityOrdered * float64(it.UnitCost))
+		po.Items = append(po.Items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	receipts, err := repo.getReceipts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	po.Receipts = receipts
+
+	return &po, nil
+}
+
+func (repo *PurchaseOrderRepository) getReceipts(ctx context.Context, poID int) ([]models.GoodsReceipt, error) {
+	rows, err := repo.db.QueryContext(ctx, `
+        SELECT r.id, r.note, r.received_at, ri.product_id, ri.quantity,
+               COALESCE(ri.unit, ''), COALESCE(ri.base_quantity, ri.quantity), ri.unit_cost
+        FROM goods_receipts r
+        JOIN goods_receipt_items ri ON ri.goods_receipt_id = r.id
+        WHERE r.purchase_order_id = $1
+        ORDER BY r.received_at, r.id, ri.id
+    `, poID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]models.GoodsReceipt, 0)
+	for rows.Next() {
+		var r models.GoodsReceipt
+		var it models.GoodsReceiptItem
+		if err := rows.Scan(&r.ID, &r.Note, &r.ReceivedAt, &it.ProductID, &it.Quantity, &it.Unit, &it.BaseQuantity, &it.UnitCost); err != nil {
+			return nil, err
+		}
+		// baris berurutan per receipt, gabungkan item ke receipt terakhir
+		if n := len(out); n > 0 && out[n-1].ID == r.ID {
+			out[n-1].Items = append(out[n-1].Items, it)
+			continue
+		}
+		r.PurchaseOrderID = poID
+		r.Items = []models.GoodsReceiptItem{it}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Receive - terima barang (boleh sebagian, boleh dalam satuan lain seperti dus). Stok bertambah
+// dalam satuan dasar, harga modal produk diperbarui ke harga beli terakhir per satuan dasar,
+// dan tiap item dicatat sebagai stock movement.
+func (repo *PurchaseOrderRepository) Receive(ctx context.Context, id int, req models.ReceiveRequest) (*models.GoodsReceipt, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("purchase_order.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status != models.PurchaseOrderOpen && status != models.PurchaseOrderPartial {
+		return nil, models.Conflict("purchase_order.cannot_receive", "status", status)
+	}
+
+	receipt := models.GoodsReceipt{
+		PurchaseOrderID: id,
+		Note:            req.Note,
+		Items:           make([]models.GoodsReceiptItem, 0, len(req.Items)),
+	}
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO goods_receipts (purchase_order_id, note) VALUES ($1, $2) RETURNING id, received_at",
+		id, req.Note,
+	).Scan(&receipt.ID, &receipt.ReceivedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range req.Items {
+		var itemID, unitCost int
+		var ordered, received float64
+		err := tx.QueryRowContext(ctx, `
+            SELECT id, quantity_ordered, quantity_received, unit_cost
+            FROM purchase_order_items
+            WHERE purchase_order_id = $1 AND product_id = $2
+            FOR UPDATE
+        `, id, item.ProductID).Scan(&itemID, &ordered, &received, &unitCost)
+		if err == sql.ErrNoRows {
+			return nil, models.Invalid("purchase_order.product_not_ordered", "product_id", item.ProductID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// PO dicatat dalam satuan dasar, barang boleh diterima per dus/pack
+		var name, baseUnit string
+		var precision int
+		err = tx.QueryRowContext(ctx, "SELECT name, base_unit, quantity_precision FROM product WHERE id = $1", item.ProductID).Scan(&name, &baseUnit, &precision)
+		if err != nil {
+			return nil, err
+		}
+		if err := models.ValidateQuantity(item.Quantity, precision, name); err != nil {
+			return nil, err
+		}
+		factor, _, unitName, err := resolveUnit(ctx, tx, item.ProductID, baseUnit, 0, item.Unit)
+		if err != nil {
+			return nil, err
+		}
+		item.Unit = unitName
+		item.BaseQuantity = models.RoundQuantity(item.Quantity*float64(factor), precision)
+
+		if remaining := models.RoundQuantity(ordered-received, models.MaxQuantityPrecision); item.BaseQuantity > remaining {
+			return nil, models.Invalid("purchase_order.receive_exceeds_remaining",
+				"product_id", item.ProductID, "quantity", item.BaseQuantity, "remaining", remaining, "unit", baseUnit)
+		}
+
+		// harga modal produk selalu per satuan dasar
+		baseCost := unitCost
+		if item.UnitCost == 0 {
+			item.UnitCost = unitCost * factor
+		} else {
+			baseCost = (item.UnitCost + factor/2) / factor
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE purchase_order_items SET quantity_received = quantity_received + $1 WHERE id = $2", item.BaseQuantity, itemID)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO goods_receipt_items (goods_receipt_id, product_id, quantity, unit, base_quantity, unit_cost) VALUES ($1, $2, $3, $4, $5, $6)",
+			receipt.ID, item.ProductID, item.Quantity, item.Unit, item.BaseQuantity, item.UnitCost,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE product SET stock = stock + $1, cost_price = $2 WHERE id = $3", item.BaseQuantity, baseCost, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		refID := id
+		m := models.StockMovement{
+			ProductID:     item.ProductID,
+			Quantity:      item.BaseQuantity,
+			Reason:        models.ReasonReceived,
+			ReferenceType: "purchase_order",
+			ReferenceID:   &refID,
+			Note:          req.Note,
+		}
+		if err := insertMovement(ctx, tx, &m); err != nil {
+			return nil, err
+		}
+
+		receipt.Items = append(receipt.Items, item)
+	}
+
+	var outstanding int
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM purchase_order_items WHERE purchase_order_id = $1 AND quantity_received < quantity_ordered",
+		id,
+	).Scan(&outstanding)
+	if err != nil {
+		return nil, err
+	}
+
+	newStatus := models.PurchaseOrderPartial
+	if outstanding == 0 {
+		newStatus = models.PurchaseOrderReceived
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE purchase_orders SET status = $1 WHERE id = $2", newStatus, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+// Cancel - batalkan PO yang belum pernah menerima barang. Baris PO dikunci supaya tidak balapan
+// dengan Receive yang berjalan bersamaan.
+func (repo *PurchaseOrderRepository) Cancel(ctx context.Context, id int) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return models.NotFound("purchase_order.not_found")
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.PurchaseOrderOpen {
+		return models.Conflict("purchase_order.cannot_cancel", "status", status)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE purchase_orders SET status = $1 WHERE id = $2", models.PurchaseOrderCancelled, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,425 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"kasir-api/models"
+)
+
+type StockRepository struct {
+	db *sql.DB
+}
+
+func NewStockRepository(db *sql.DB) *StockRepository {
+	return &StockRepository{db: db}
+}
+
+// insertMovement - catat pergerakan stok di dalam transaksi yang sedang berjalan
+func insertMovement(ctx context.Context, tx *sql.Tx, m *models.StockMovement) error {
+	query := `
+        INSERT INTO stock_movements (product_id, variant_id, quantity, reason, reference_type, reference_id, note)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+        RETURNING id, created_at
+    `
+	var variantID, refID interface{}
+	if m.VariantID != nil {
+		variantID = *m.VariantID
+	}
+	if m.ReferenceID != nil {
+		refID = *m.ReferenceID
+	}
+	return tx.QueryRowContext(ctx, query, m.ProductID, variantID, m.Quantity, m.Reason, m.ReferenceType, refID, m.Note).Scan(&m.ID, &m.CreatedAt)
+}
+
+// CreateAdjustment - ubah stok satu produk secara manual + catat movement
+func (repo *StockRepository) CreateAdjustment(ctx context.Context, req models.StockAdjustmentRequest) (*models.StockMovement, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if req.VariantID != nil {
+		return repo.createVariantAdjustment(ctx, tx, req)
+	}
+
+	var name string
+	var stock float64
+	var precision int
+	err = tx.QueryRowContext(ctx,
+		"SELECT name, stock, quantity_precision FROM product WHERE id = $1 FOR UPDATE",
+		req.ProductID,
+	).Scan(&name, &stock, &precision)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("product.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !models.HasPrecision(req.Quantity, precision) {
+		return nil, models.Invalid("validation.quantity_precision", "product", name, "precision", precision)
+	}
+
+	if stock+req.Quantity < 0 {
+		return nil, models.InsufficientStock("stock.insufficient_available",
+			"product", name, "product_id", req.ProductID, "available", stock)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE product SET stock = stock + $1 WHERE id = $2", req.Quantity, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := models.StockMovement{
+		ProductID:     req.ProductID,
+		ProductName:   name,
+		Quantity:      req.Quantity,
+		Reason:        req.Reason,
+		ReferenceType: "adjustment",
+		Note:          req.Note,
+	}
+	if err := insertMovement(ctx, tx, &m); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// createVariantAdjustment - sama seperti CreateAdjustment tapi untuk stok varian (selalu bilangan bulat)
+func (repo *StockRepository) createVariantAdjustment(ctx context.Context, tx *sql.Tx, req models.StockAdjustmentRequest) (*models.StockMovement, error) {
+	var name, variantName string
+	var stock int
+	err := tx.QueryRowContext(ctx, `
+        SELECT p.name, v.name, v.stock
+        FROM product_variants v
+        JOIN product p ON p.id = v.product_id
+        WHERE v.id = $1 AND v.product_id = $2
+        FOR UPDATE OF v
+    `, *req.VariantID, req.ProductID).Scan(&name, &variantName, &stock)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("variant.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !models.HasPrecision(req.Quantity, 0) {
+		return nil, models.Invalid("validation.quantity_precision", "product", name+" "+variantName, "precision", 0)
+	}
+
+	if float64(stock)+req.Quantity < 0 {
+		return nil, models.InsufficientStock("stock.insufficient_available",
+			"product", name+" "+variantName, "product_id", req.ProductID, "available", stock)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE product_variants SET stock = stock + $1 WHERE id = $2", req.Quantity, *req.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := models.StockMovement{
+		ProductID:     req.ProductID,
+		ProductName:   name,
+		VariantID:     req.VariantID,
+		VariantName:   variantName,
+		Quantity:      req.Quantity,
+		Reason:        req.Reason,
+		ReferenceType: "adjustment",
+		Note:          req.Note,
+	}
+	if err := insertMovement(ctx, tx, &m); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetMovements - riwayat pergerakan stok, productID 0 = semua produk
+func (repo *StockRepository) GetMovements(ctx context.Context, productID int) ([]models.StockMovement, error) {
+	query := `
+        SELECT m.id, m.product_id, p.name, m.variant_id, COALESCE(v.name, ''), m.quantity, m.reason,
+               COALESCE(m.reference_type, ''), m.reference_id, m.note, m.created_at
+        FROM stock_movements m
+        JOIN product p ON p.id = m.product_id
+        LEFT JOIN product_variants v ON v.id = m.variant_id
+    `
+	var args []interface{}
+	if productID != 0 {
+		query += " WHERE m.product_id = $1"
+		args = append(args, productID)
+	}
+	query += " ORDER BY m.created_at DESC, m.id DESC"
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]models.StockMovement, 0)
+	for rows.Next() {
+		var m models.StockMovement
+		var variantID, refID sql.NullInt64
+		if err := rows.Scan(
+			&m.ID, &m.ProductID, &m.ProductName, &variantID, &m.VariantName, &m.Quantity, &m.Reason,
+			&m.ReferenceType, &refID, &m.Note, &m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if variantID.Valid {
+			v := int(variantID.Int64)
+			m.VariantID = &v
+		}
+		if refID.Valid {
+			v := int(refID.Int64)
+			m.ReferenceID = &v
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (repo *StockRepository) CreateStockTake(ctx context.Context, st *models.StockTake) error {
+	query := "INSERT INTO stock_takes (note) VALUES ($1) RETURNING id, status, created_at"
+	return repo.db.QueryRowContext(ctx, query, st.Note).Scan(&st.ID, &st.Status, &st.CreatedAt)
+}
+
+func (repo *StockRepository) GetAllStockTakes(ctx context.Context) ([]models.StockTake, error) {
+	query := `
+        SELECT id, status, note, COALESCE(posted_by, ''), created_at, posted_at
+        FROM stock_takes
+        ORDER BY created_at DESC, id DESC
+    `
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]models.StockTake, 0)
+	for rows.Next() {
+		var st models.StockTake
+		var postedAt sql.NullTime
+		if err := rows.Scan(&st.ID, &st.Status, &st.Note, &st.PostedBy, &st.CreatedAt, &postedAt); err != nil {
+			return nil, err
+		}
+		if postedAt.Valid {
+			st.PostedAt = &postedAt.Time
+		}
+		out = append(out, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetStockTakeByID - sesi opname + item beserta selisihnya.
+// Selama masih draft, selisih dihitung terhadap product.stock saat ini.
+func (repo *StockRepository) GetStockTakeByID(ctx context.Context, id int) (*models.StockTake, error) {
+	query := `
+        SELECT id, status, note, COALESCE(posted_by, ''), created_at, posted_at
+        FROM stock_takes WHERE id = $1
+    `
+	var st models.StockTake
+	var postedAt sql.NullTime
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(&st.ID, &st.Status, &st.Note, &st.PostedBy, &st.CreatedAt, &postedAt)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("stock_take.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if postedAt.Valid {
+		st.PostedAt = &postedAt.Time
+	}
+
+	rows, err := repo.db.QueryContext(ctx, `
+        SELECT i.id, i.stock_take_id, i.product_id, p.name,
+               CASE WHEN s.status = 'draft' THEN p.stock ELSE i.system_stock END,
+               i.counted_stock, i.reason
+        FROM stock_take_items i
+        JOIN stock_takes s ON s.id = i.stock_take_id
+        JOIN product p ON p.id = i.product_id
+        WHERE i.stock_take_id = $1
+        ORDER BY p.name ASC
+    `, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	st.Items = make([]models.StockTakeItem, 0)
+	for rows.Next() {
+		var it models.StockTakeItem
+		if err := rows.Scan(
+			&it.ID, &it.StockTakeID, &it.ProductID, &it.ProductName,
+			&it.SystemStock, &it.CountedStock, &it.Reason,
+		); err != nil {
+			return nil, err
+		}
+		it.Variance = models.RoundQuantity(it.CountedStock-it.SystemStock, models.MaxQuantityPrecision)
+		st.Items = append(st.Items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &st, nil
+}
+
+// SaveCounts - simpan hasil hitung fisik, produk yang sudah ada di sesi akan ditimpa
+func (repo *StockRepository) SaveCounts(ctx context.Context, id int, items []models.StockCountItem) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM stock_takes WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return models.NotFound("stock_take.not_found")
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.StockTakeDraft {
+		return models.Conflict("stock_take.already_posted")
+	}
+
+	for _, item := range items {
+		var name string
+		var precision int
+		err := tx.QueryRowContext(ctx, "SELECT name, quantity_precision FROM product WHERE id = $1", item.ProductID).Scan(&name, &precision)
+		if err == sql.ErrNoRows {
+			return models.NotFound("product.id_not_found", "product_id", item.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+		if !models.HasPrecision(item.CountedStock, precision) {
+			return models.Invalid("stock_take.counted_precision", "product", name, "precision", precision)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO stock_take_items (stock_take_id, product_id, counted_stock, reason)
+            VALUES ($1, $2, $3, $4)
+            ON CONFLICT (stock_take_id, product_id)
+            DO UPDATE SET counted_stock = EXCLUDED.counted_stock, reason = EXCLUDED.reason
+        `, id, item.ProductID, item.CountedStock, item.Reason)
+		if err != nil {
+			return fmt.Errorf("simpan hitungan produk id %d: %w", item.ProductID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PostStockTake - terapkan selisih opname ke product.stock dan catat sebagai stock movement
+func (repo *StockRepository) PostStockTake(ctx context.Context, id int, postedBy string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM stock_takes WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return models.NotFound("stock_take.not_found")
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.StockTakeDraft {
+		return models.Conflict("stock_take.already_posted")
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT i.id, i.product_id, p.name, p.stock, i.counted_stock, i.reason
+        FROM stock_take_items i
+        JOIN product p ON p.id = i.product_id
+        WHERE i.stock_take_id = $1
+        ORDER BY i.product_id
+        FOR UPDATE OF p
+    `, id)
+	if err != nil {
+		return err
+	}
+
+	items := make([]models.StockTakeItem, 0)
+	for rows.Next() {
+		var it models.StockTakeItem
+		if err := rows.Scan(&it.ID, &it.ProductID, &it.ProductName, &it.SystemStock, &it.CountedStock, &it.Reason); err != nil {
+			rows.Close()
+			return err
+		}
+		it.Variance = models.RoundQuantity(it.CountedStock-it.SystemStock, models.MaxQuantityPrecision)
+		items = append(items, it)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return models.Invalid("stock_take.no_items")
+	}
+
+	for _, it := range items {
+		if it.Variance != 0 && it.Reason == "" {
+			return models.Invalid("stock_take.reason_required", "product", it.ProductName)
+		}
+	}
+
+	for _, it := range items {
+		_, err := tx.ExecContext(ctx, "UPDATE stock_take_items SET system_stock = $1 WHERE id = $2", it.SystemStock, it.ID)
+		if err != nil {
+			return err
+		}
+		if it.Variance == 0 {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE product SET stock = $1 WHERE id = $2", it.CountedStock, it.ProductID)
+		if err != nil {
+			return err
+		}
+
+		refID := id
+		m := models.StockMovement{
+			ProductID:     it.ProductID,
+			Quantity:      it.Variance,
+			Reason:        it.Reason,
+			ReferenceType: "stock_take",
+			ReferenceID:   &refID,
+		}
+		if err := insertMovement(ctx, tx, &m); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE stock_takes SET status = $1, posted_by = $2, posted_at = NOW() WHERE id = $3",
+		models.StockTakePosted, postedBy, id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
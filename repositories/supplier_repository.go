@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"kasir-api/models"
+)
+
+type SupplierRepository struct {
+	db *sql.DB
+}
+
+func NewSupplierRepository(db *sql.DB) *SupplierRepository {
+	return &SupplierRepository{db: db}
+}
+
+func (repo *SupplierRepository) GetAll(ctx context.Context) ([]models.Supplier, error) {
+	query := "SELECT id, name, phone, email, address FROM supplier ORDER BY name"
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]models.Supplier, 0)
+	for rows.Next() {
+		var s models.Supplier
+		err := rows.Scan(&s.ID, &s.Name, &s.Phone, &s.Email, &s.Address)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, s)
+	}
+
+	return suppliers, nil
+}
+
+func (repo *SupplierRepository) Create(ctx context.Context, supplier *models.Supplier) error {
+	query := "INSERT INTO supplier (name, phone, email, address) VALUES ($1, $2, $3, $4) RETURNING id"
+	err := repo.db.QueryRowContext(ctx, query, supplier.Name, supplier.Phone, supplier.Email, supplier.Address).Scan(&supplier.ID)
+	return err
+}
+
+// GetByID - ambil supplier by ID
+func (repo *SupplierRepository) GetByID(ctx context.Context, id int) (*models.Supplier, error) {
+	query := "SELECT id, name, phone, email, address FROM supplier WHERE id = $1"
+
+	var s models.Supplier
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(&s.ID, &s.Name, &s.Phone, &s.Email, &s.Address)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("supplier.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (repo *SupplierRepository) Update(ctx context.Context, supplier *models.Supplier) error {
+	query := "UPDATE supplier SET name = $1, phone = $2, email = $3, address = $4 WHERE id = $5"
+	result, err := repo.db.ExecContext(ctx, query, supplier.Name, supplier.Phone, supplier.Email, supplier.Address, supplier.ID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return models.NotFound("supplier.not_found")
+	}
+
+	return nil
+}
+
+func (repo *SupplierRepository) Delete(ctx context.Context, id int) error {
+	query := "DELETE FROM supplier WHERE id = $1"
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if isForeignKeyViolation(err) {
+		return models.Conflict("supplier.in_use")
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return models.NotFound("supplier.not_found")
+	}
+
+	return nil
+}
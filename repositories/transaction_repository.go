@@ -3,9 +3,12 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"kasir-api/models"
 	"strings"
+	"time"
 )
 
 type TransactionRepository struct {
@@ -16,57 +19,249 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-func (repo *TransactionRepository) CreateTransaction(items []models.CheckoutItem) (*models.Transaction, error) {
+// CreateTransaction - simpan transaksi checkout. Selain transaksi, dikembalikan juga
+// daftar produk yang stoknya baru saja turun melewati min_stock karena checkout ini.
+func (repo *TransactionRepository) CreateTransaction(ctx context.Context, req models.CheckoutRequest) (*models.Transaction, []models.LowStockAlert, error) {
 	var (
-		res *models.Transaction
+		res    *models.Transaction
+		alerts []models.LowStockAlert
 	)
 
-	tx, err := repo.db.Begin()
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer tx.Rollback()
 
+	priceList, err := checkoutPriceList(ctx, tx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// inisialisasi subtotal -> jumlah total transaksi keseluruhan
 	totalAmount := 0
 	// inisialisasi modeling transactionDetails -> nanti kita insert ke db
 	details := make([]models.TransactionDetail, 0)
 	// loop setiap item
-	for _, item := range items {
-		var productName string
-		var productID, price, stock int
-		// get product dapet pricing
-		err := tx.QueryRow("SELECT id, name, price, stock FROM product WHERE id=$1", item.ProductID).Scan(&productID, &productName, &price, &stock)
+	for _, item := range req.Items {
+		var productName, baseUnit string
+		var productID, price, precision int
+		var weighted, archived bool
+		// get product dapet pricing, by id atau by PLU dari barcode timbangan
+		column, key := "id", any(item.ProductID)
+		if item.ProductID == 0 && item.PLU != "" {
+			column, key = "plu", item.PLU
+		}
+		err := tx.QueryRowContext(ctx,
+			"SELECT id, name, price, base_unit, weighted, quantity_precision, archived FROM product WHERE "+column+"=$1",
+			key,
+		).Scan(&productID, &productName, &price, &baseUnit, &weighted, &precision, &archived)
+		if err == sql.ErrNoRows && column == "plu" {
+			return nil, nil, models.NotFound("product.plu_not_found", "plu", item.PLU)
+		}
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product id %d not found", item.ProductID)
+			return nil, nil, models.NotFound("product.id_not_found", "product_id", item.ProductID)
 		}
 
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if archived {
+			return nil, nil, models.Invalid("checkout.product_archived", "product", productName)
 		}
 
-		subtotal := item.Quantity * price
-		totalAmount += subtotal
+		// harga khusus dari daftar harga menggantikan harga normal produk
+		normalPrice := price
+		listPrice := false
+		if priceList != nil && item.EmbeddedPrice == 0 {
+			p, ok, err := priceListPrice(ctx, tx, priceList.ID, productID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				price, listPrice = p, true
+			}
+		}
 
-		_, err = tx.Exec("UPDATE product SET stock = stock - $1 WHERE id = $2", item.Quantity, productID)
+		quantity := item.Quantity
+		if item.EmbeddedPrice > 0 {
+			// barcode berisi harga: berat dihitung balik dari harga per kg
+			if !weighted || price <= 0 {
+				return nil, nil, models.Invalid("checkout.not_weighted", "product", productName)
+			}
+			quantity = models.RoundQuantity(float64(item.EmbeddedPrice)/float64(price), precision)
+		}
+		if err := models.ValidateQuantity(quantity, precision, productName); err != nil {
+			return nil, nil, err
+		}
+
+		bundles, err := componentsByProduct(ctx, tx, []int{productID})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		components := bundles[productID]
+
+		if item.Unit != "" && (weighted || item.VariantID != nil || len(components) > 0) {
+			return nil, nil, models.Invalid("checkout.unit_not_supported", "product", productName)
+		}
+
+		var variantName, unitName string
+		var tier *models.PriceTier
+		baseQuantity := quantity
+		switch {
+		case item.VariantID != nil:
+			// varian punya harga dan stok sendiri, stok produk induk tidak disentuh.
+			// stok varian bilangan bulat, produk timbang tidak boleh punya varian
+			if weighted {
+				return nil, nil, models.Invalid("checkout.variant_weighted", "product", productName)
+			}
+			listedPrice := price
+			err := tx.QueryRowContext(ctx,
+				"SELECT name, price FROM product_variants WHERE id = $1 AND product_id = $2",
+				*item.VariantID, productID,
+			).Scan(&variantName, &price)
+			if err == sql.ErrNoRows {
+				return nil, nil, models.NotFound("variant.not_found_for_product", "variant_id", *item.VariantID, "product_id", productID)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			variantPrice := price
+			if listPrice {
+				// daftar harga per produk: potongannya dari harga normal berlaku juga untuk varian
+				price = discountedVariantPrice(variantPrice, normalPrice, listedPrice)
+			}
+			// harga grosir per produk, min_quantity dihitung dari quantity varian di baris ini
+			t, err := resolvePriceTier(ctx, tx, productID, quantity)
+			if err != nil {
+				return nil, nil, err
+			}
+			if t != nil {
+				if p := discountedVariantPrice(variantPrice, normalPrice, t.Price); p < price {
+					price, tier = p, t
+				}
+			}
+
+			alert, err := decrementVariantStock(ctx, tx, *item.VariantID, productName, variantName, quantity)
+			if err != nil {
+				return nil, nil, err
+			}
+			if alert != nil {
+				alerts = append(alerts, *alert)
+			}
+		case len(components) > 0:
+			// paket: stok yang berkurang adalah stok tiap komponen, semua harus tersedia
+			for _, c := range components {
+				if c.Archived {
+					return nil, nil, models.Invalid("checkout.component_archived", "bundle", productName, "product", c.ProductName)
+				}
+			}
+			for _, c := range components {
+				alert, err := decrementStock(ctx, tx, c.ProductID, c.ProductName, float64(c.Quantity)*quantity, true)
+				var de *models.DomainError
+				if errors.As(err, &de) {
+					return nil, nil, de.With("bundle", productName)
+				}
+				if err != nil {
+					return nil, nil, err
+				}
+				if alert != nil {
+					alerts = append(alerts, *alert)
+				}
+			}
+		default:
+			var hasVariants bool
+			err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM product_variants WHERE product_id = $1)", productID).Scan(&hasVariants)
+			if err != nil {
+				return nil, nil, err
+			}
+			if hasVariants {
+				return nil, nil, models.Invalid("checkout.variant_required", "product", productName)
+			}
+
+			// harga per satuan yang dipilih, stok selalu dikurangi dalam satuan dasar
+			basePrice := price
+			factor, unitPrice, name, err := resolveUnit(ctx, tx, productID, baseUnit, price, item.Unit)
+			if err != nil {
+				return nil, nil, err
+			}
+			unitName = name
+			baseQuantity = quantity * float64(factor)
+
+			// harga grosir dihitung dari quantity satuan dasar di baris ini
+			var t *models.PriceTier
+			if item.EmbeddedPrice == 0 {
+				t, err = resolvePriceTier(ctx, tx, productID, baseQuantity)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			price, tier = unitLinePrice(unitPrice, basePrice, factor, listPrice, t)
+
+			alert, err := decrementStock(ctx, tx, productID, productName, baseQuantity, false)
+			if err != nil {
+				return nil, nil, err
+			}
+			if alert != nil {
+				alerts = append(alerts, *alert)
+			}
+		}
+
+		modifiers, modifierPrice, err := resolveModifiers(ctx, tx, productID, productName, item.ModifierIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// produk timbang: harga per kg x berat, dibulatkan ke rupiah terdekat
+		subtotal := models.RoundRupiah(quantity * float64(price+modifierPrice))
+		if item.EmbeddedPrice > 0 {
+			// harga yang tercetak di label timbangan yang berlaku
+			subtotal = item.EmbeddedPrice + models.RoundRupiah(quantity*float64(modifierPrice))
+		}
+		totalAmount += subtotal
+
+		var allocations []models.BundleAllocation
+		if len(components) > 0 {
+			amounts := allocateBundlePrice(subtotal, components)
+			for i, c := range components {
+				allocations = append(allocations, models.BundleAllocation{
+					ProductID:       c.ProductID,
+					ProductName:     c.ProductName,
+					Quantity:        float64(c.Quantity) * quantity,
+					AllocatedAmount: amounts[i],
+				})
+			}
 		}
 
 		// item nya dimasukkin ke transactionDetails
 		details = append(details, models.TransactionDetail{
-			ProductID:   productID,
-			ProductName: productName,
-			Quantity:    item.Quantity,
-			Subtotal:    subtotal,
+			ProductID:    productID,
+			ProductName:  productName,
+			VariantID:    item.VariantID,
+			VariantName:  variantName,
+			Quantity:     quantity,
+			Unit:         unitName,
+			BaseQuantity: baseQuantity,
+			UnitPrice:    price,
+			PriceTier:    tier,
+			Modifiers:    modifiers,
+			Components:   allocations,
+			Subtotal:     subtotal,
 		})
 	}
 
 	// insert transaction
 	var transactionID int
-	err = tx.QueryRow("INSERT INTO transactions (total_amount) VALUES ($1) RETURNING ID", totalAmount).Scan(&transactionID)
+	var priceListID *int
+	if priceList != nil {
+		priceListID = &priceList.ID
+	}
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO transactions (total_amount, customer_id, price_list_id) VALUES ($1, $2, $3) RETURNING ID",
+		totalAmount, nullableID(req.CustomerID), nullableID(priceListID),
+	).Scan(&transactionID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// insert transaction details (bulk)
@@ -80,45 +275,216 @@ func (repo *TransactionRepository) CreateTransaction(items []models.CheckoutItem
 			args []any
 		)
 
-		sb.WriteString("INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal) VALUES ")
+		sb.WriteString("INSERT INTO transaction_details (transaction_id, product_id, variant_id, quantity, unit, base_quantity, unit_price, tier_min_quantity, tier_price, modifiers, subtotal) VALUES ")
 
 		// total kolom per row
-		const cols = 4
+		const cols = 11
 		for i, d := range details {
 			if i > 0 {
 				sb.WriteString(",")
 			}
 			base := i*cols + 1
-			// ($base, $base+1, $base+2, $base+3)
-			sb.WriteString(fmt.Sprintf("($%d,$%d,$%d,$%d)", base, base+1, base+2, base+3))
+			// ($base, $base+1, ..., $base+10)
+			sb.WriteString("(")
+			for c := 0; c < cols; c++ {
+				if c > 0 {
+					sb.WriteString(",")
+				}
+				sb.WriteString(fmt.Sprintf("$%d", base+c))
+			}
+			sb.WriteString(")")
 
+			var variantID, unit, tierMin, tierPrice any
+			if d.VariantID != nil {
+				variantID = *d.VariantID
+			}
+			if d.PriceTier != nil {
+				tierMin, tierPrice = d.PriceTier.MinQuantity, d.PriceTier.Price
+			}
+			if d.Unit != "" {
+				unit = d.Unit
+			}
+			modifiers, err := json.Marshal(d.Modifiers)
+			if err != nil {
+				return nil, nil, err
+			}
+			if d.Modifiers == nil {
+				modifiers = []byte("[]")
+			}
 			args = append(args,
 				transactionID,
 				d.ProductID,
+				variantID,
 				d.Quantity,
+				unit,
+				d.BaseQuantity,
+				d.UnitPrice,
+				tierMin,
+				tierPrice,
+				string(modifiers),
 				d.Subtotal,
 			)
 		}
 
-		if _, err := tx.Exec(sb.String(), args...); err != nil {
-			return nil, fmt.Errorf("bulk insert transaction_details: %w", err)
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			return nil, nil, fmt.Errorf("bulk insert transaction_details: %w", err)
+		}
+	}
+
+	// alokasi harga paket per komponen
+	for _, d := range details {
+		for _, c := range d.Components {
+			_, err := tx.ExecContext(ctx, `
+                INSERT INTO transaction_bundle_components
+                    (transaction_id, bundle_product_id, component_product_id, quantity, allocated_amount)
+                VALUES ($1, $2, $3, $4, $5)
+            `, transactionID, d.ProductID, c.ProductID, c.Quantity, c.AllocatedAmount)
+			if err != nil {
+				return nil, nil, fmt.Errorf("insert bundle allocation: %w", err)
+			}
 		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res = &models.Transaction{
 		ID:          transactionID,
 		TotalAmount: totalAmount,
+		CustomerID:  req.CustomerID,
+		PriceListID: priceListID,
 		Details:     details,
 	}
 
-	return res, nil
+	for i := range alerts {
+		alerts[i].TransactionID = transactionID
+	}
+
+	return res, alerts, nil
+}
+
+// checkoutPriceList - daftar harga yang berlaku untuk checkout ini: price_list_id eksplisit,
+// kalau tidak ada dari grup pelanggan. Daftar harga eksplisit di luar masa berlaku ditolak,
+// sedangkan daftar harga grup yang sudah/belum berlaku diabaikan (harga normal).
+func checkoutPriceList(ctx context.Context, tx *sql.Tx, req models.CheckoutRequest) (*models.PriceList, error) {
+	listID := req.PriceListID
+	if req.CustomerID != nil {
+		groupListID, err := customerPriceListID(ctx, tx, *req.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if listID == nil {
+			listID = groupListID
+		}
+	}
+	if listID == nil {
+		return nil, nil
+	}
+
+	pl, err := getPriceList(ctx, tx, *listID)
+	if err != nil {
+		return nil, err
+	}
+	if !pl.ActiveAt(time.Now()) {
+		if req.PriceListID != nil {
+			return nil, models.Invalid("price_list.inactive", "name", pl.Name)
+		}
+		return nil, nil
+	}
+	return pl, nil
+}
+
+// unitLinePrice - harga per satuan yang dipilih: harga satuan (atau harga khusus daftar harga per satuan
+// dasar x factor), lalu harga grosir jika lebih murah. Kembalikan tier jika tier yang dipakai.
+func unitLinePrice(unitPrice, basePrice, factor int, listPrice bool, t *models.PriceTier) (int, *models.PriceTier) {
+	price := unitPrice
+	if listPrice {
+		// harga satuan besar ikut harga khusus per satuan dasar
+		price = basePrice * factor
+	}
+	if t != nil && t.Price*factor < price {
+		return t.Price * factor, t
+	}
+	return price, nil
+}
+
+// discountedVariantPrice - harga khusus (daftar harga / grosir) disimpan per produk, varian mendapat
+// potongan yang sama dari harga normal produk, tidak kurang dari 0
+func discountedVariantPrice(variantPrice, normalPrice, specialPrice int) int {
+	return max(variantPrice-(normalPrice-specialPrice), 0)
+}
+
+// decrementVariantStock - kurangi stok varian, tolak jika tidak mencukupi. min_stock produk induk
+// berlaku per varian. qty sudah lolos cek presisi 0 (produk timbang tidak punya varian),
+// jadi dikirim apa adanya tanpa dibulatkan.
+func decrementVariantStock(ctx context.Context, tx *sql.Tx, variantID int, productName, variantName string, qty float64) (*models.LowStockAlert, error) {
+	alert := models.LowStockAlert{ProductName: productName, VariantID: &variantID, VariantName: variantName}
+	var crossed bool
+	err := tx.QueryRowContext(ctx, `
+        UPDATE product_variants v SET stock = v.stock - $1
+        FROM product p
+        WHERE v.id = $2 AND p.id = v.product_id AND v.stock >= $1
+        RETURNING p.id, v.stock, p.min_stock, p.reorder_qty,
+                  p.min_stock > 0 AND v.stock + $1 > p.min_stock AND v.stock <= p.min_stock
+    `, qty, variantID).Scan(&alert.ProductID, &alert.Stock, &alert.MinStock, &alert.ReorderQty, &crossed)
+	if err == sql.ErrNoRows {
+		var available int
+		if err := tx.QueryRowContext(ctx, "SELECT stock FROM product_variants WHERE id = $1", variantID).Scan(&available); err != nil {
+			return nil, err
+		}
+		return nil, models.InsufficientStock("stock.insufficient_available",
+			"product", productName+" "+variantName, "variant_id", variantID, "available", available)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !crossed {
+		return nil, nil
+	}
+	return &alert, nil
+}
+
+// decrementStock - kurangi stok produk dan kembalikan alert jika stok baru saja melewati min_stock.
+// strict = tolak jika stok tidak mencukupi.
+func decrementStock(ctx context.Context, tx *sql.Tx, productID int, productName string, qty float64, strict bool) (*models.LowStockAlert, error) {
+	query := "UPDATE product SET stock = stock - $1 WHERE id = $2"
+	if strict {
+		query += " AND stock >= $1"
+	}
+	// cek "baru saja melewati min_stock" dihitung di SQL dari stok lama (stock + $1 di RETURNING),
+	// supaya tidak ada selisih pembulatan float di sisi Go
+	query += ` RETURNING stock, min_stock, reorder_qty,
+        min_stock > 0 AND stock + $1 > min_stock AND stock <= min_stock`
+
+	var newStock float64
+	var minStock, reorderQty int
+	var crossed bool
+	err := tx.QueryRowContext(ctx, query, qty, productID).Scan(&newStock, &minStock, &reorderQty, &crossed)
+	if err == sql.ErrNoRows {
+		return nil, models.InsufficientStock("stock.insufficient", "product", productName, "product_id", productID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// hanya kirim alert saat melewati batas, bukan tiap checkout selama stok masih rendah
+	if !crossed {
+		return nil, nil
+	}
+	return &models.LowStockAlert{
+		ProductID:   productID,
+		ProductName: productName,
+		Stock:       newStock,
+		MinStock:    minStock,
+		ReorderQty:  reorderQty,
+	}, nil
 }
 
-func (repo *TransactionRepository) GetSummaryToday(ctx context.Context) (*models.SummaryToday, error) {
+// GetSummaryToday - ringkasan transaksi dengan created_at di [from, to), batas hari dihitung service
+// sesuai TIMEZONE toko, bukan timezone session database
+func (repo *TransactionRepository) GetSummaryToday(ctx context.Context, from, to time.Time) (*models.SummaryToday, error) {
 	var (
 		totalRevenue   sql.NullInt64
 		totalTransaksi int
@@ -129,9 +495,9 @@ func (repo *TransactionRepository) GetSummaryToday(ctx context.Context) (*models
             COALESCE(SUM(t.total_amount), 0) AS total_revenue,
             COUNT(*) AS total_transaksi
         FROM transactions t
-        WHERE t.created_at >= CURRENT_DATE
-          AND t.created_at < CURRENT_DATE + INTERVAL '1 day'
-    `).Scan(&totalRevenue, &totalTransaksi)
+        WHERE t.created_at >= $1
+          AND t.created_at < $2
+    `, from, to).Scan(&totalRevenue, &totalTransaksi)
 	if err != nil {
 		return nil, fmt.Errorf("query summary today: %w", err)
 	}
@@ -142,16 +508,16 @@ func (repo *TransactionRepository) GetSummaryToday(ctx context.Context) (*models
 	)
 
 	err = repo.db.QueryRowContext(ctx, `
-        SELECT p.name AS nama, SUM(td.quantity) AS qty_terjual
+        SELECT p.name AS nama, SUM(COALESCE(td.base_quantity, td.quantity))::BIGINT AS qty_terjual
         FROM transaction_details td
         JOIN transactions t ON t.id = td.transaction_id
         JOIN product p ON p.id = td.product_id
-        WHERE t.created_at >= CURRENT_DATE
-          AND t.created_at < CURRENT_DATE + INTERVAL '1 day'
+        WHERE t.created_at >= $1
+          AND t.created_at < $2
         GROUP BY p.name
         ORDER BY qty_terjual DESC, p.name ASC
         LIMIT 1
-    `).Scan(&bestName, &bestQty)
+    `, from, to).Scan(&bestName, &bestQty)
 
 	produkTerlaris := models.Product{}
 	if err == sql.ErrNoRows {
@@ -174,22 +540,22 @@ func (repo *TransactionRepository) GetSummaryToday(ctx context.Context) (*models
 	}, nil
 }
 
-func (repo *TransactionRepository) GetBestSellerToday(ctx context.Context) (string, int, error) {
+func (repo *TransactionRepository) GetBestSellerToday(ctx context.Context, from, to time.Time) (string, int, error) {
 	var (
 		name sql.NullString
 		qty  sql.NullInt64
 	)
 	err := repo.db.QueryRowContext(ctx, `
-			SELECT p.name AS nama, SUM(td.quantity) AS qty_terjual
+			SELECT p.name AS nama, SUM(COALESCE(td.base_quantity, td.quantity))::BIGINT AS qty_terjual
 			FROM transaction_details td
 			JOIN transactions t ON t.id = td.transaction_id
 			JOIN product p ON p.id = td.product_id -- ganti jadi "products" jika skema kamu jamak
-			WHERE t.created_at >= CURRENT_DATE
-			AND t.created_at < CURRENT_DATE + INTERVAL '1 day'
+			WHERE t.created_at >= $1
+			AND t.created_at < $2
 			GROUP BY p.name
 			ORDER BY qty_terjual DESC, p.name ASC
 			LIMIT 1;
-    `).Scan(&name, &qty)
+    `, from, to).Scan(&name, &qty)
 	if err == sql.ErrNoRows {
 		return "", 0, nil
 	}
@@ -0,0 +1,26 @@
+package repositories
+
+import "testing"
+
+func TestDiscountedVariantPrice(t *testing.T) {
+	tests := []struct {
+		name                     string
+		variant, normal, special int
+		want                     int
+	}{
+		// normal 5000, tier 4500, varian 6000 -> 5500 (contoh di readme)
+		{name: "potongan grosir", variant: 6000, normal: 5000, special: 4500, want: 5500},
+		// normal 10000, khusus 9000, varian 12000 -> 11000
+		{name: "daftar harga", variant: 12000, normal: 10000, special: 9000, want: 11000},
+		{name: "tanpa potongan", variant: 6000, normal: 5000, special: 5000, want: 6000},
+		{name: "varian lebih murah dari induk", variant: 3000, normal: 5000, special: 4000, want: 2000},
+		{name: "tidak pernah negatif", variant: 500, normal: 5000, special: 1000, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := discountedVariantPrice(tt.variant, tt.normal, tt.special); got != tt.want {
+				t.Errorf("discountedVariantPrice(%d, %d, %d) = %d, ingin %d", tt.variant, tt.normal, tt.special, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type UnitRepository struct {
+	db *sql.DB
+}
+
+func NewUnitRepository(db *sql.DB) *UnitRepository {
+	return &UnitRepository{db: db}
+}
+
+// unitsByProduct - satuan tambahan untuk banyak produk sekaligus
+func unitsByProduct(ctx context.Context, q queryer, productIDs []int) (map[int][]models.ProductUnit, error) {
+	out := make(map[int][]models.ProductUnit)
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+        SELECT id, product_id, name, conversion_factor, price
+        FROM product_units
+        WHERE product_id = ANY($1)
+        ORDER BY product_id, conversion_factor, id
+    `, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u models.ProductUnit
+		if err := rows.Scan(&u.ID, &u.ProductID, &u.Name, &u.ConversionFactor, &u.Price); err != nil {
+			return nil, err
+		}
+		out[u.ProductID] = append(out[u.ProductID], u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// resolveUnit - cari faktor konversi dan harga per satuan. unit kosong atau sama dengan
+// base_unit berarti satuan dasar (faktor 1, harga dasar).
+func resolveUnit(ctx context.Context, q queryer, productID int, baseUnit string, basePrice int, unit string) (factor, price int, name string, err error) {
+	if unit == "" || strings.EqualFold(unit, baseUnit) {
+		return 1, basePrice, baseUnit, nil
+	}
+
+	err = q.QueryRowContext(ctx,
+		"SELECT name, conversion_factor, price FROM product_units WHERE product_id = $1 AND LOWER(name) = LOWER($2)",
+		productID, unit,
+	).Scan(&name, &factor, &price)
+	if err == sql.ErrNoRows {
+		return 0, 0, "", models.NotFound("unit.not_found_for_product", "unit", unit, "product_id", productID)
+	}
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	if price == 0 {
+		price = basePrice * factor
+	}
+	return factor, price, name, nil
+}
+
+func (repo *UnitRepository) GetByProduct(ctx context.Context, productID int) ([]models.ProductUnit, error) {
+	grouped, err := unitsByProduct(ctx, repo.db, []int{productID})
+	if err != nil {
+		return nil, err
+	}
+	units := grouped[productID]
+	if units == nil {
+		units = make([]models.ProductUnit, 0)
+	}
+	return units, nil
+}
+
+func (repo *UnitRepository) Create(ctx context.Context, u *models.ProductUnit) error {
+	query := "INSERT INTO product_units (product_id, name, conversion_factor, price) VALUES ($1, $2, $3, $4) RETURNING id"
+	err := repo.db.QueryRowContext(ctx, query, u.ProductID, u.Name, u.ConversionFactor, u.Price).Scan(&u.ID)
+	return mapUnitError(err)
+}
+
+// GetByID - ambil satuan by ID
+func (repo *UnitRepository) GetByID(ctx context.Context, id int) (*models.ProductUnit, error) {
+	query := "SELECT id, product_id, name, conversion_factor, price FROM product_units WHERE id = $1"
+
+	var u models.ProductUnit
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(&u.ID, &u.ProductID, &u.Name, &u.ConversionFactor, &u.Price)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("unit.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func (repo *UnitRepository) Update(ctx context.Context, u *models.ProductUnit) error {
+	query := "UPDATE product_units SET name = $1, conversion_factor = $2, price = $3 WHERE id = $4 RETURNING product_id"
+	err := repo.db.QueryRowContext(ctx, query, u.Name, u.ConversionFactor, u.Price, u.ID).Scan(&u.ProductID)
+	if err == sql.ErrNoRows {
+		return models.NotFound("unit.not_found")
+	}
+	return mapUnitError(err)
+}
+
+func (repo *UnitRepository) Delete(ctx context.Context, id int) error {
+	query := "DELETE FROM product_units WHERE id = $1"
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return models.NotFound("unit.not_found")
+	}
+
+	return nil
+}
+
+func mapUnitError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return models.Conflict("unit.name_taken")
+		case "23503":
+			return models.NotFound("product.not_found")
+		}
+	}
+	return err
+}
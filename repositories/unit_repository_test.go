@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestResolveBaseUnit(t *testing.T) {
+	// satuan dasar tidak perlu query, queryer nil tidak disentuh
+	for _, unit := range []string{"", "pcs", "PCS"} {
+		factor, price, name, err := resolveUnit(context.Background(), nil, 1, "pcs", 3500, unit)
+		if err != nil || factor != 1 || price != 3500 || name != "pcs" {
+			t.Errorf("resolveUnit(%q) = %d, %d, %q, %v; ingin 1, 3500, pcs, nil", unit, factor, price, name, err)
+		}
+	}
+}
+
+func TestUnitLinePrice(t *testing.T) {
+	tier := &models.PriceTier{MinQuantity: 48, Price: 3000}
+
+	tests := []struct {
+		name      string
+		unitPrice int
+		listPrice bool
+		tier      *models.PriceTier
+		wantPrice int
+		wantTier  bool
+	}{
+		// 1 dus = 24 pcs @3500, harga dus diset 80000
+		{name: "harga satuan", unitPrice: 80000, wantPrice: 80000},
+		{name: "daftar harga per satuan dasar", unitPrice: 80000, listPrice: true, wantPrice: 24 * 3200},
+		{name: "grosir lebih murah", unitPrice: 80000, tier: tier, wantPrice: 24 * 3000, wantTier: true},
+		{name: "grosir tidak lebih murah", unitPrice: 70000, tier: tier, wantPrice: 70000},
+		{name: "grosir vs daftar harga", unitPrice: 80000, listPrice: true, tier: tier, wantPrice: 24 * 3000, wantTier: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// basePrice 3200 = harga khusus per pcs dari daftar harga
+			price, applied := unitLinePrice(tt.unitPrice, 3200, 24, tt.listPrice, tt.tier)
+			if price != tt.wantPrice {
+				t.Errorf("price = %d, ingin %d", price, tt.wantPrice)
+			}
+			if (applied != nil) != tt.wantTier {
+				t.Errorf("tier = %v, ingin dipakai: %v", applied, tt.wantTier)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"kasir-api/models"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type VariantRepository struct {
+	db *sql.DB
+}
+
+func NewVariantRepository(db *sql.DB) *VariantRepository {
+	return &VariantRepository{db: db}
+}
+
+// variantsByProduct - ambil semua varian untuk daftar produk sekaligus, dikelompokkan per product_id
+func variantsByProduct(ctx context.Context, db *sql.DB, productIDs []int) (map[int][]models.ProductVariant, error) {
+	out := make(map[int][]models.ProductVariant)
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+        SELECT id, product_id, name, sku, price, stock
+        FROM product_variants
+        WHERE product_id = ANY($1)
+        ORDER BY product_id, price, id
+    `, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.ProductVariant
+		if err := rows.Scan(&v.ID, &v.ProductID, &v.Name, &v.SKU, &v.Price, &v.Stock); err != nil {
+			return nil, err
+		}
+		out[v.ProductID] = append(out[v.ProductID], v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (repo *VariantRepository) GetByProduct(ctx context.Context, productID int) ([]models.ProductVariant, error) {
+	grouped, err := variantsByProduct(ctx, repo.db, []int{productID})
+	if err != nil {
+		return nil, err
+	}
+	variants := grouped[productID]
+	if variants == nil {
+		variants = make([]models.ProductVariant, 0)
+	}
+	return variants, nil
+}
+
+func (repo *VariantRepository) Create(ctx context.Context, v *models.ProductVariant) error {
+	// stok varian bilangan bulat, berat produk timbang tidak bisa dicatat di varian.
+	// Paket dan komponen paket juga tidak boleh punya varian (sama seperti BundleRepository.SetComponents),
+	// karena checkout paket mengurangi stok produk komponen, bukan stok varian.
+	var weighted, isBundle, isComponent bool
+	err := repo.db.QueryRowContext(ctx, `
+        SELECT p.weighted,
+               EXISTS (SELECT 1 FROM bundle_components WHERE bundle_product_id = p.id),
+               EXISTS (SELECT 1 FROM bundle_components WHERE component_product_id = p.id)
+        FROM product p WHERE p.id = $1
+    `, v.ProductID).Scan(&weighted, &isBundle, &isComponent)
+	if err == sql.ErrNoRows {
+		return models.NotFound("product.not_found")
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case weighted:
+		return models.Invalid("variant.weighted_product")
+	case isBundle:
+		return models.Invalid("variant.bundle_product")
+	case isComponent:
+		return models.Invalid("variant.bundle_component")
+	}
+
+	query := "INSERT INTO product_variants (product_id, name, sku, price, stock) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	err = repo.db.QueryRowContext(ctx, query, v.ProductID, v.Name, v.SKU, v.Price, v.Stock).Scan(&v.ID)
+	return mapVariantError(err)
+}
+
+// GetByID - ambil varian by ID
+func (repo *VariantRepository) GetByID(ctx context.Context, id int) (*models.ProductVariant, error) {
+	query := "SELECT id, product_id, name, sku, price, stock FROM product_variants WHERE id = $1"
+
+	var v models.ProductVariant
+	err := repo.db.QueryRowContext(ctx, query, id).Scan(&v.ID, &v.ProductID, &v.Name, &v.SKU, &v.Price, &v.Stock)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFound("variant.not_found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Update - product_id varian tidak bisa dipindah ke produk lain. Stok tidak ikut diubah,
+// perubahan stok varian lewat penyesuaian stok supaya tercatat di stock_movements.
+func (repo *VariantRepository) Update(ctx context.Context, v *models.ProductVariant) error {
+	query := "UPDATE product_variants SET name = $1, sku = $2, price = $3 WHERE id = $4 RETURNING product_id, stock"
+	err := repo.db.QueryRowContext(ctx, query, v.Name, v.SKU, v.Price, v.ID).Scan(&v.ProductID, &v.Stock)
+	if err == sql.ErrNoRows {
+		return models.NotFound("variant.not_found")
+	}
+	return mapVariantError(err)
+}
+
+func (repo *VariantRepository) Delete(ctx context.Context, id int) error {
+	query := "DELETE FROM product_variants WHERE id = $1"
+	result, err := repo.db.ExecContext(ctx, query, id)
+	if isForeignKeyViolation(err) {
+		return models.Conflict("variant.in_use")
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return models.NotFound("variant.not_found")
+	}
+
+	return nil
+}
+
+func mapVariantError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "23505" && strings.Contains(pqErr.Constraint, "sku"):
+			return models.Conflict("variant.sku_taken")
+		case pqErr.Code == "23503":
+			return models.NotFound("product.not_found")
+		}
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+type BundleService struct {
+	repo *repositories.BundleRepository
+}
+
+func NewBundleService(repo *repositories.BundleRepository) *BundleService {
+	return &BundleService{repo: repo}
+}
+
+func (s *BundleService) GetAll(ctx context.Context) ([]models.Product, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *BundleService) SetComponents(ctx context.Context, bundleID int, components []models.BundleComponent) error {
+	seen := make(map[int]bool, len(components))
+	for _, c := range components {
+		if c.ProductID <= 0 {
+			return models.Invalid("bundle.component_product_required")
+		}
+		if c.ProductID == bundleID {
+			return models.Invalid("bundle.contains_itself")
+		}
+		if seen[c.ProductID] {
+			return models.Invalid("validation.product_duplicate", "product_id", c.ProductID)
+		}
+		seen[c.ProductID] = true
+		if c.Quantity <= 0 {
+			return models.Invalid("bundle.component_quantity_not_positive", "product_id", c.ProductID)
+		}
+	}
+	return s.repo.SetComponents(ctx, bundleID, components)
+}
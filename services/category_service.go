@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"kasir-api/models"
 	"kasir-api/repositories"
 )
@@ -13,22 +14,49 @@ func NewCategoryService(repo *repositories.CategoryRepository) *CategoryService
 	return &CategoryService{repo: repo}
 }
 
-func (s *CategoryService) GetAll() ([]models.Category, error) {
-	return s.repo.GetAll()
+func (s *CategoryService) GetAll(ctx context.Context, includeArchived bool) ([]models.Category, error) {
+	return s.repo.GetAll(ctx, includeArchived)
 }
 
-func (s *CategoryService) Create(data *models.Category) error {
-	return s.repo.Create(data)
+func (s *CategoryService) GetTree(ctx context.Context, includeArchived bool) ([]models.Category, error) {
+	return s.repo.GetTree(ctx, includeArchived)
 }
 
-func (s *CategoryService) GetByID(id int) (*models.Category, error) {
-	return s.repo.GetByID(id)
+func (s *CategoryService) Create(ctx context.Context, data *models.Category) error {
+	return s.repo.Create(ctx, data)
 }
 
-func (s *CategoryService) Update(product *models.Category) error {
-	return s.repo.Update(product)
+func (s *CategoryService) GetByID(ctx context.Context, id int) (*models.Category, error) {
+	return s.repo.GetByID(ctx, id)
 }
 
-func (s *CategoryService) Delete(id int) error {
-	return s.repo.Delete(id)
+// Update - parent ke diri sendiri ditolak tanpa query, siklus lebih dalam dicek repository
+func (s *CategoryService) Update(ctx context.Context, category *models.Category) error {
+	if category.ParentID != nil && *category.ParentID == category.ID {
+		return models.Invalid("category.parent_cycle")
+	}
+	return s.repo.Update(ctx, category)
+}
+
+// Delete - mode kosong = reject, supaya produk tidak pernah kehilangan kategori tanpa sengaja
+func (s *CategoryService) Delete(ctx context.Context, id int, mode string, targetID *int) (*models.CategoryDeleteResult, error) {
+	switch mode {
+	case "":
+		mode = models.CategoryDeleteReject
+	case models.CategoryDeleteReject, models.CategoryDeleteUncategorize:
+	case models.CategoryDeleteReassign:
+		if targetID == nil {
+			return nil, models.Invalid("category.target_required")
+		}
+		if *targetID == id {
+			return nil, models.Invalid("category.target_is_self")
+		}
+	default:
+		return nil, models.Invalid("category.invalid_delete_mode")
+	}
+	return s.repo.Delete(ctx, id, mode, targetID)
+}
+
+func (s *CategoryService) Restore(ctx context.Context, id int) error {
+	return s.repo.Restore(ctx, id)
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestCategoryDeleteValidation(t *testing.T) {
+	// mode dicek sebelum repository dipanggil
+	s := &CategoryService{}
+	self := 3
+
+	tests := []struct {
+		name     string
+		mode     string
+		targetID *int
+		wantKey  string
+	}{
+		{name: "mode tidak dikenal", mode: "cascade", wantKey: "category.invalid_delete_mode"},
+		{name: "mode huruf besar", mode: "Reject", wantKey: "category.invalid_delete_mode"},
+		{name: "reassign tanpa target", mode: models.CategoryDeleteReassign, wantKey: "category.target_required"},
+		{name: "reassign ke diri sendiri", mode: models.CategoryDeleteReassign, targetID: &self, wantKey: "category.target_is_self"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Delete(context.Background(), self, tt.mode, tt.targetID)
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Code != models.CodeValidation || de.Key != tt.wantKey {
+				t.Fatalf("err = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestCategoryUpdateRejectsSelfParent(t *testing.T) {
+	s := &CategoryService{}
+	id := 3
+	err := s.Update(context.Background(), &models.Category{ID: id, Name: "Minuman", ParentID: &id})
+	var de *models.DomainError
+	if !errors.As(err, &de) || de.Key != "category.parent_cycle" {
+		t.Fatalf("err = %v, ingin key category.parent_cycle", err)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type CustomerService struct {
+	repo *repositories.CustomerRepository
+}
+
+func NewCustomerService(repo *repositories.CustomerRepository) *CustomerService {
+	return &CustomerService{repo: repo}
+}
+
+func (s *CustomerService) GetAllGroups(ctx context.Context) ([]models.CustomerGroup, error) {
+	return s.repo.GetAllGroups(ctx)
+}
+
+func (s *CustomerService) GetGroupByID(ctx context.Context, id int) (*models.CustomerGroup, error) {
+	return s.repo.GetGroupByID(ctx, id)
+}
+
+func (s *CustomerService) CreateGroup(ctx context.Context, data *models.CustomerGroup) error {
+	if strings.TrimSpace(data.Name) == "" {
+		return models.Invalid("customer_group.name_required")
+	}
+	return s.repo.CreateGroup(ctx, data)
+}
+
+func (s *CustomerService) UpdateGroup(ctx context.Context, data *models.CustomerGroup) error {
+	if strings.TrimSpace(data.Name) == "" {
+		return models.Invalid("customer_group.name_required")
+	}
+	return s.repo.UpdateGroup(ctx, data)
+}
+
+func (s *CustomerService) DeleteGroup(ctx context.Context, id int) error {
+	return s.repo.DeleteGroup(ctx, id)
+}
+
+func (s *CustomerService) GetAll(ctx context.Context, groupID int) ([]models.Customer, error) {
+	return s.repo.GetAll(ctx, groupID)
+}
+
+func (s *CustomerService) GetByID(ctx context.Context, id int) (*models.Customer, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *CustomerService) Create(ctx context.Context, data *models.Customer) error {
+	if strings.TrimSpace(data.Name) == "" {
+		return models.Invalid("customer.name_required")
+	}
+	return s.repo.Create(ctx, data)
+}
+
+func (s *CustomerService) Update(ctx context.Context, data *models.Customer) error {
+	if strings.TrimSpace(data.Name) == "" {
+		return models.Invalid("customer.name_required")
+	}
+	return s.repo.Update(ctx, data)
+}
+
+func (s *CustomerService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
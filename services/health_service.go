@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"kasir-api/database"
+	"kasir-api/models"
+	"log/slog"
+	"math"
+	"time"
+)
+
+type HealthService struct {
+	db *sql.DB
+	// timeout - batas seluruh pengecekan, load balancer biasanya memberi 2-5 detik
+	timeout time.Duration
+}
+
+func NewHealthService(db *sql.DB, timeout time.Duration) *HealthService {
+	return &HealthService{db: db, timeout: timeout}
+}
+
+// Ready - cek database bisa dihubungi dan semua migrasi sudah dijalankan, isi pool hanya dilaporkan
+func (s *HealthService) Ready(ctx context.Context) models.HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	// pool dibaca lebih dulu, ping di bawah ikut memakai satu koneksi
+	components := map[string]models.HealthCheck{
+		"pool": s.checkPool(),
+	}
+	components["database"] = s.checkDatabase(ctx)
+	if components["database"].Status == models.HealthUp {
+		components["migrations"] = s.checkMigrations(ctx)
+	} else {
+		components["migrations"] = models.HealthCheck{Status: models.HealthDown, Key: "health.database_unreachable"}
+	}
+
+	report := models.HealthReport{Status: models.HealthUp, Components: components}
+	for _, c := range components {
+		if c.Status != models.HealthUp {
+			report.Status = models.HealthDown
+		}
+	}
+	return report
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) models.HealthCheck {
+	start := time.Now()
+	err := s.db.PingContext(ctx)
+	latency := float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		// error asli (host, user) hanya di log, endpoint health bisa diakses dari luar
+		slog.WarnContext(ctx, "health: ping database gagal", "err", err)
+		return models.HealthCheck{Status: models.HealthDown, Key: "health.ping_failed",
+			Details: map[string]any{"latency_ms": latency, "timeout": s.timeout.String()}}
+	}
+	return models.HealthCheck{Status: models.HealthUp, Details: map[string]any{"latency_ms": latency}}
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) models.HealthCheck {
+	pending, err := database.PendingMigrations(ctx, s.db)
+	if err != nil {
+		slog.WarnContext(ctx, "health: gagal membaca schema_migrations", "err", err)
+		return models.HealthCheck{Status: models.HealthDown, Key: "health.migrations_unreadable"}
+	}
+	if len(pending) > 0 {
+		return models.HealthCheck{Status: models.HealthDown, Key: "health.migrations_pending",
+			Details: map[string]any{"pending": pending}}
+	}
+	return models.HealthCheck{Status: models.HealthUp, Details: map[string]any{"applied": len(database.Migrations())}}
+}
+
+// checkPool - isi connection pool, hanya informasi. Pool penuh saat jam ramai terjadi di semua instance
+// sekaligus, jika dijadikan alasan 503 load balancer akan mengeluarkan semua instance bersamaan.
+func (s *HealthService) checkPool() models.HealthCheck {
+	stats := s.db.Stats()
+	ratio := 0.0
+	if stats.MaxOpenConnections > 0 {
+		ratio = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+	}
+	return models.HealthCheck{Status: models.HealthUp, Details: map[string]any{
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+		"max_open":         stats.MaxOpenConnections,
+		"saturation":       math.Round(ratio*100) / 100,
+		"wait_count":       stats.WaitCount,
+		"wait_duration_ms": stats.WaitDuration.Milliseconds(),
+	}}
+}
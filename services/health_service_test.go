@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"kasir-api/models"
+	"testing"
+	"time"
+)
+
+// unreachableDB - sql.Open tidak menghubungi server, ping ke port 1 langsung ditolak
+func unreachableDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("postgres", "host=127.0.0.1 port=1 user=kasir dbname=kasir sslmode=disable connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(4)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestHealthReadyDatabaseDown(t *testing.T) {
+	s := NewHealthService(unreachableDB(t), 2*time.Second)
+	report := s.Ready(context.Background())
+
+	if report.Status != models.HealthDown {
+		t.Fatalf("status = %s, ingin down", report.Status)
+	}
+	db := report.Components["database"]
+	if db.Status != models.HealthDown || db.Key != "health.ping_failed" || db.Details["timeout"] != "2s" {
+		t.Errorf("database = %+v", db)
+	}
+	// error koneksi asli tidak ikut ke response
+	if db.Error != "" {
+		t.Errorf("database.error = %q, diisi handler bukan service", db.Error)
+	}
+	if m := report.Components["migrations"]; m.Status != models.HealthDown || m.Key != "health.database_unreachable" {
+		t.Errorf("migrations = %+v, ingin down karena database", m)
+	}
+
+	// pool hanya informasi, tidak pernah membuat instance down
+	pool := report.Components["pool"]
+	if pool.Status != models.HealthUp || pool.Details["max_open"] != 4 || pool.Details["saturation"] != 0.0 {
+		t.Errorf("pool = %+v", pool)
+	}
+}
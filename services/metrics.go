@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CheckoutObserver - dipanggil (sync) setelah checkout, transaction nil dan err terisi jika gagal
+type CheckoutObserver func(ctx context.Context, transaction *models.Transaction, err error)
+
+// NewCheckoutMetrics - hitung checkout berhasil / gagal per alasan dan total penjualan rupiah
+func NewCheckoutMetrics(reg prometheus.Registerer) CheckoutObserver {
+	factory := promauto.With(reg)
+	checkouts := factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "kasir_checkouts_total",
+		Help: "Jumlah checkout per hasil (success/failure) dan alasan gagal (key error).",
+	}, []string{"result", "reason"})
+	amount := factory.NewCounter(prometheus.CounterOpts{
+		Name: "kasir_sales_amount_rupiah_total",
+		Help: "Total nilai penjualan dari checkout yang berhasil, dalam rupiah.",
+	})
+	items := factory.NewCounter(prometheus.CounterOpts{
+		Name: "kasir_sales_items_total",
+		Help: "Jumlah baris item pada checkout yang berhasil.",
+	})
+
+	return func(ctx context.Context, transaction *models.Transaction, err error) {
+		if err != nil {
+			checkouts.WithLabelValues("failure", checkoutFailureReason(ctx, err)).Inc()
+			return
+		}
+		checkouts.WithLabelValues("success", "none").Inc()
+		amount.Add(float64(transaction.TotalAmount))
+		items.Add(float64(len(transaction.Details)))
+	}
+}
+
+// checkoutFailureReason - key domain error (mis. stock.insufficient_available), jumlahnya terbatas
+// sesuai katalog pesan sehingga aman dipakai sebagai label. lib/pq tidak mengembalikan ctx.Err()
+// saat query dibatalkan, jadi context-nya juga dicek.
+func checkoutFailureReason(ctx context.Context, err error) string {
+	var de *models.DomainError
+	switch {
+	case errors.As(err, &de):
+		return de.Key
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled), errors.Is(ctx.Err(), context.Canceled):
+		return "canceled"
+	}
+	return "internal_error"
+}
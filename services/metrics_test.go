@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCheckoutMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	observe := NewCheckoutMetrics(reg)
+	ctx := context.Background()
+
+	observe(ctx, &models.Transaction{TotalAmount: 25000, Details: make([]models.TransactionDetail, 2)}, nil)
+	observe(ctx, &models.Transaction{TotalAmount: 7500, Details: make([]models.TransactionDetail, 1)}, nil)
+	observe(ctx, nil, models.Invalid("stock.insufficient_available", "product", "Indomie"))
+	observe(ctx, nil, models.Invalid("stock.insufficient_available", "product", "Aqua"))
+	observe(ctx, nil, context.DeadlineExceeded)
+	expired, cancel := context.WithCancel(ctx)
+	cancel()
+	// lib/pq mengembalikan error biasa saat query dibatalkan, alasan diambil dari context
+	observe(expired, nil, errors.New("pq: canceling statement due to user request"))
+	observe(ctx, nil, errors.New("pq: connection refused"))
+
+	golden := `
+# HELP kasir_checkouts_total Jumlah checkout per hasil (success/failure) dan alasan gagal (key error).
+# TYPE kasir_checkouts_total counter
+kasir_checkouts_total{reason="canceled",result="failure"} 1
+kasir_checkouts_total{reason="internal_error",result="failure"} 1
+kasir_checkouts_total{reason="none",result="success"} 2
+kasir_checkouts_total{reason="stock.insufficient_available",result="failure"} 2
+kasir_checkouts_total{reason="timeout",result="failure"} 1
+# HELP kasir_sales_amount_rupiah_total Total nilai penjualan dari checkout yang berhasil, dalam rupiah.
+# TYPE kasir_sales_amount_rupiah_total counter
+kasir_sales_amount_rupiah_total 32500
+# HELP kasir_sales_items_total Jumlah baris item pada checkout yang berhasil.
+# TYPE kasir_sales_items_total counter
+kasir_sales_items_total 3
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(golden)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type ModifierService struct {
+	repo *repositories.ModifierRepository
+}
+
+func NewModifierService(repo *repositories.ModifierRepository) *ModifierService {
+	return &ModifierService{repo: repo}
+}
+
+func (s *ModifierService) GetAll(ctx context.Context) ([]models.ModifierGroup, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *ModifierService) GetByID(ctx context.Context, id int) (*models.ModifierGroup, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ModifierService) Create(ctx context.Context, data *models.ModifierGroup) error {
+	if err := validateModifierGroup(data); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, data)
+}
+
+func (s *ModifierService) Update(ctx context.Context, data *models.ModifierGroup) error {
+	if err := validateModifierGroup(data); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, data)
+}
+
+func (s *ModifierService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func validateModifierGroup(g *models.ModifierGroup) error {
+	if strings.TrimSpace(g.Name) == "" {
+		return models.Invalid("modifier_group.name_required")
+	}
+	if g.MinSelect < 0 || g.MaxSelect < 0 {
+		return models.Invalid("modifier_group.select_negative")
+	}
+	if g.MaxSelect > 0 && g.MinSelect > g.MaxSelect {
+		return models.Invalid("modifier_group.min_exceeds_max")
+	}
+	if len(g.Options) == 0 {
+		return models.Invalid("modifier_group.options_empty")
+	}
+	if g.MinSelect > len(g.Options) {
+		return models.Invalid("modifier_group.min_exceeds_options", "min", g.MinSelect)
+	}
+	for _, o := range g.Options {
+		if strings.TrimSpace(o.Name) == "" {
+			return models.Invalid("modifier_option.name_required")
+		}
+		if o.Price < 0 {
+			return models.Invalid("modifier_option.price_negative", "option", o.Name)
+		}
+	}
+	return nil
+}
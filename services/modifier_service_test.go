@@ -0,0 +1,43 @@
+package services
+
+import (
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestValidateModifierGroup(t *testing.T) {
+	opts := []models.ModifierOption{{Name: "Boba", Price: 5000}, {Name: "Jelly", Price: 4000}}
+
+	tests := []struct {
+		name    string
+		group   models.ModifierGroup
+		wantKey string
+	}{
+		{name: "valid", group: models.ModifierGroup{Name: "Topping", MaxSelect: 2, Options: opts}},
+		{name: "max 0 tanpa batas", group: models.ModifierGroup{Name: "Topping", MinSelect: 2, Options: opts}},
+		{name: "nama kosong", group: models.ModifierGroup{Name: " ", Options: opts}, wantKey: "modifier_group.name_required"},
+		{name: "min negatif", group: models.ModifierGroup{Name: "Topping", MinSelect: -1, Options: opts}, wantKey: "modifier_group.select_negative"},
+		{name: "max negatif", group: models.ModifierGroup{Name: "Topping", MaxSelect: -1, Options: opts}, wantKey: "modifier_group.select_negative"},
+		{name: "min melebihi max", group: models.ModifierGroup{Name: "Topping", MinSelect: 2, MaxSelect: 1, Options: opts}, wantKey: "modifier_group.min_exceeds_max"},
+		{name: "tanpa opsi", group: models.ModifierGroup{Name: "Topping"}, wantKey: "modifier_group.options_empty"},
+		{name: "min melebihi jumlah opsi", group: models.ModifierGroup{Name: "Topping", MinSelect: 3, Options: opts}, wantKey: "modifier_group.min_exceeds_options"},
+		{name: "nama opsi kosong", group: models.ModifierGroup{Name: "Topping", Options: []models.ModifierOption{{Name: ""}}}, wantKey: "modifier_option.name_required"},
+		{name: "harga opsi negatif", group: models.ModifierGroup{Name: "Topping", Options: []models.ModifierOption{{Name: "Keju", Price: -1}}}, wantKey: "modifier_option.price_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateModifierGroup(&tt.group)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("validateModifierGroup() error = %v", err)
+				}
+				return
+			}
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("validateModifierGroup() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"kasir-api/models"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// LowStockNotifier - dipanggil (async) setiap ada produk yang stoknya turun melewati min_stock
+type LowStockNotifier func(alert models.LowStockAlert)
+
+// LogLowStockNotifier - notifier default, cukup tulis ke log server
+func LogLowStockNotifier(alert models.LowStockAlert) {
+	slog.Warn("stok menipis", "product_id", alert.ProductID, "product", alert.ProductName,
+		"variant_id", alert.VariantID, "stock", alert.Stock, "min_stock", alert.MinStock, "reorder_qty", alert.ReorderQty)
+}
+
+// NewWebhookLowStockNotifier - kirim alert sebagai JSON POST ke url (mis. bot WhatsApp/Telegram owner)
+func NewWebhookLowStockNotifier(url string) LowStockNotifier {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	return func(alert models.LowStockAlert) {
+		body, err := json.Marshal(map[string]any{
+			"event": "product.low_stock",
+			"data":  alert,
+		})
+		if err != nil {
+			slog.Error("low stock webhook", "product_id", alert.ProductID, "err", err)
+			return
+		}
+
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			slog.Error("low stock webhook", "product_id", alert.ProductID, "err", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			slog.Error("low stock webhook", "product_id", alert.ProductID, "status", resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"kasir-api/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookLowStockNotifier(t *testing.T) {
+	var got struct {
+		Event string               `json:"event"`
+		Data  models.LowStockAlert `json:"data"`
+	}
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("body webhook: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	variantID := 9
+	alert := models.LowStockAlert{
+		ProductID: 3, ProductName: "Kaos", VariantID: &variantID, VariantName: "XL",
+		Stock: 2, MinStock: 5, ReorderQty: 24, TransactionID: 77,
+	}
+	// notifier dipanggil sinkron di sini, TransactionService yang menjalankannya di goroutine
+	NewWebhookLowStockNotifier(srv.URL)(alert)
+
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q", contentType)
+	}
+	if got.Event != "product.low_stock" {
+		t.Errorf("event = %q, ingin product.low_stock", got.Event)
+	}
+	if got.Data.ProductID != 3 || got.Data.Stock != 2 || got.Data.MinStock != 5 || got.Data.ReorderQty != 24 || got.Data.TransactionID != 77 {
+		t.Errorf("data = %+v", got.Data)
+	}
+	if got.Data.VariantID == nil || *got.Data.VariantID != 9 || got.Data.VariantName != "XL" {
+		t.Errorf("varian tidak ikut terkirim: %+v", got.Data)
+	}
+}
+
+func TestWebhookLowStockNotifierServerError(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	// gagal hanya dicatat di log, tidak panic dan tidak retry
+	NewWebhookLowStockNotifier(srv.URL)(models.LowStockAlert{ProductID: 1})
+	if calls != 1 {
+		t.Errorf("webhook dipanggil %d kali, ingin 1", calls)
+	}
+}
+
+func TestLowStockAlertJSONOmitsEmptyVariant(t *testing.T) {
+	body, err := json.Marshal(models.LowStockAlert{ProductID: 1, ProductName: "Gula", Stock: 1.5, MinStock: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"variant_id", "variant_name", "transaction_id"} {
+		if _, ok := m[key]; ok {
+			t.Errorf("%s ikut terkirim untuk alert produk tanpa varian: %s", key, body)
+		}
+	}
+}
+
+func TestValidateThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		product models.Product
+		wantErr bool
+	}{
+		{name: "tanpa batas", product: models.Product{}},
+		{name: "batas diset", product: models.Product{MinStock: 5, ReorderQty: 24}},
+		{name: "min_stock negatif", product: models.Product{MinStock: -1}, wantErr: true},
+		{name: "reorder_qty negatif", product: models.Product{MinStock: 5, ReorderQty: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateThreshold(&tt.product)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("validateThreshold() error = %v", err)
+				}
+				return
+			}
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != "product.min_stock_negative" {
+				t.Fatalf("validateThreshold() error = %v, ingin product.min_stock_negative", err)
+			}
+		})
+	}
+}
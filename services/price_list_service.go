@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type PriceListService struct {
+	repo *repositories.PriceListRepository
+}
+
+func NewPriceListService(repo *repositories.PriceListRepository) *PriceListService {
+	return &PriceListService{repo: repo}
+}
+
+func (s *PriceListService) GetAll(ctx context.Context) ([]models.PriceList, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *PriceListService) GetByID(ctx context.Context, id int) (*models.PriceList, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *PriceListService) Create(ctx context.Context, data *models.PriceList) error {
+	if err := validatePriceList(data); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, data)
+}
+
+func (s *PriceListService) Update(ctx context.Context, data *models.PriceList) error {
+	if err := validatePriceList(data); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, data)
+}
+
+func (s *PriceListService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func validatePriceList(pl *models.PriceList) error {
+	if strings.TrimSpace(pl.Name) == "" {
+		return models.Invalid("price_list.name_required")
+	}
+	if pl.ValidFrom != nil && pl.ValidUntil != nil && !pl.ValidUntil.After(*pl.ValidFrom) {
+		return models.Invalid("price_list.invalid_period")
+	}
+	for _, it := range pl.Items {
+		if it.ProductID <= 0 {
+			return models.Invalid("validation.product_id_required")
+		}
+		if it.Price < 0 {
+			return models.Invalid("price_list.item_price_negative", "product_id", it.ProductID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+	"time"
+)
+
+func TestValidatePriceList(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name    string
+		pl      models.PriceList
+		wantKey string
+	}{
+		{name: "valid", pl: models.PriceList{Name: "Member", ValidFrom: &from, ValidUntil: &until, Items: []models.PriceListItem{{ProductID: 1, Price: 9000}}}},
+		{name: "tanpa periode", pl: models.PriceList{Name: "Grosir"}},
+		{name: "nama kosong", pl: models.PriceList{Name: " "}, wantKey: "price_list.name_required"},
+		{name: "periode terbalik", pl: models.PriceList{Name: "Promo", ValidFrom: &until, ValidUntil: &from}, wantKey: "price_list.invalid_period"},
+		{name: "periode nol", pl: models.PriceList{Name: "Promo", ValidFrom: &from, ValidUntil: &from}, wantKey: "price_list.invalid_period"},
+		{name: "item tanpa produk", pl: models.PriceList{Name: "Member", Items: []models.PriceListItem{{Price: 9000}}}, wantKey: "validation.product_id_required"},
+		{name: "harga item negatif", pl: models.PriceList{Name: "Member", Items: []models.PriceListItem{{ProductID: 1, Price: -1}}}, wantKey: "price_list.item_price_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePriceList(&tt.pl)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("validatePriceList() error = %v", err)
+				}
+				return
+			}
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("validatePriceList() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestCustomerNameRequired(t *testing.T) {
+	s := &CustomerService{}
+	ctx := context.Background()
+
+	checks := map[string]func() error{
+		"CreateGroup": func() error { return s.CreateGroup(ctx, &models.CustomerGroup{Name: " "}) },
+		"UpdateGroup": func() error { return s.UpdateGroup(ctx, &models.CustomerGroup{}) },
+		"Create":      func() error { return s.Create(ctx, &models.Customer{Name: ""}) },
+		"Update":      func() error { return s.Update(ctx, &models.Customer{Name: "\t"}) },
+	}
+	want := map[string]string{
+		"CreateGroup": "customer_group.name_required",
+		"UpdateGroup": "customer_group.name_required",
+		"Create":      "customer.name_required",
+		"Update":      "customer.name_required",
+	}
+	for op, fn := range checks {
+		var de *models.DomainError
+		if err := fn(); !errors.As(err, &de) || de.Key != want[op] {
+			t.Errorf("%s error = %v, ingin %s", op, err, want[op])
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"kasir-api/models"
 	"kasir-api/repositories"
+	"log/slog"
+	"strings"
+	"time"
 )
 
 type ProductService struct {
@@ -13,22 +18,251 @@ func NewProductService(repo *repositories.ProductRepository) *ProductService {
 	return &ProductService{repo: repo}
 }
 
-func (s *ProductService) GetAll(name string) ([]models.Product, error) {
-	return s.repo.GetAllWithCategory(name)
+func (s *ProductService) GetAll(ctx context.Context, f models.ProductFilter) (*models.ProductPage, error) {
+	if err := normalizeProductFilter(&f); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAllWithCategory(ctx, f)
 }
 
-func (s *ProductService) Create(data *models.Product) error {
-	return s.repo.Create(data)
+// normalizeProductFilter - limit 0 = default, limit di atas batas dipotong ke MaxProductPageLimit
+func normalizeProductFilter(f *models.ProductFilter) error {
+	if f.Sort != "" && f.Sort != "name" && f.Sort != "price" && f.Sort != "stock" {
+		return models.Invalid("product_filter.invalid_sort")
+	}
+	if f.Order != "" && f.Order != "asc" && f.Order != "desc" {
+		return models.Invalid("product_filter.invalid_order")
+	}
+	if f.Limit < 0 || f.Offset < 0 {
+		return models.Invalid("product_filter.negative_paging")
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return models.Invalid("product_filter.invalid_price_range")
+	}
+	if f.Limit == 0 {
+		f.Limit = models.DefaultProductPageLimit
+	}
+	if f.Limit > models.MaxProductPageLimit {
+		f.Limit = models.MaxProductPageLimit
+	}
+	return nil
 }
 
-func (s *ProductService) GetByID(id int) (*models.Product, error) {
-	return s.repo.GetByIdWithCategory(id)
+// Search - pencarian kasir
+func (s *ProductService) Search(ctx context.Context, term string, limit int) ([]models.ProductSearchResult, error) {
+	term, limit, err := normalizeSearch(term, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Search(ctx, term, limit)
 }
 
-func (s *ProductService) Update(product *models.Product) error {
-	return s.repo.Update(product)
+// normalizeSearch - term minimal 2 karakter supaya tidak menyapu seluruh katalog,
+// limit 0 = default dan dipotong ke MaxSearchLimit
+func normalizeSearch(term string, limit int) (string, int, error) {
+	term = strings.TrimSpace(term)
+	if len([]rune(term)) < 2 {
+		return "", 0, models.Invalid("search.query_too_short", "min", 2)
+	}
+	if limit <= 0 {
+		limit = models.DefaultSearchLimit
+	}
+	if limit > models.MaxSearchLimit {
+		limit = models.MaxSearchLimit
+	}
+	return term, limit, nil
 }
 
-func (s *ProductService) Delete(id int) error {
-	return s.repo.Delete(id)
+func (s *ProductService) GetLowStock(ctx context.Context) ([]models.Product, error) {
+	return s.repo.GetLowStock(ctx)
+}
+
+func (s *ProductService) CountLowStock(ctx context.Context) (int, error) {
+	return s.repo.CountLowStock(ctx)
+}
+
+func (s *ProductService) Create(ctx context.Context, data *models.Product) error {
+	if err := validateProduct(data); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, data)
+}
+
+func (s *ProductService) GetByID(ctx context.Context, id int) (*models.Product, error) {
+	return s.repo.GetByIdWithCategory(ctx, id)
+}
+
+// Update - gabungkan field yang dikirim dengan data produk sekarang, lalu simpan
+func (s *ProductService) Update(ctx context.Context, id int, data models.ProductUpdate) (*models.Product, error) {
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if data.Stock != nil && *data.Stock < 0 {
+		return nil, models.Invalid("product.price_stock_negative")
+	}
+	// quantity_precision lama milik produk timbang, diisi ulang validateWeighted
+	if data.Weighted != nil && *data.Weighted != product.Weighted && data.QuantityPrecision == nil {
+		product.QuantityPrecision = 0
+	}
+	data.Apply(product)
+	if data.Stock != nil {
+		// hanya untuk cek presisi, repository menyimpan stok lewat data.Stock
+		product.Stock = *data.Stock
+	}
+
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+	// harga / presisi berubah tanpa price_tiers baru: harga grosir yang tersimpan harus tetap valid
+	if data.PriceTiers == nil && (data.Price != nil || data.Weighted != nil || data.QuantityPrecision != nil) {
+		if err := s.validateExistingTiers(ctx, *product); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Update(ctx, product, data.Stock); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// validateExistingTiers - cek harga grosir tersimpan terhadap harga & presisi baru p (salinan, supaya
+// repository tidak menulis ulang tier yang tidak dikirim)
+func (s *ProductService) validateExistingTiers(ctx context.Context, p models.Product) error {
+	tiers, err := s.repo.GetPriceTiers(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	p.PriceTiers = tiers
+	err = validatePriceTiers(&p)
+	var de *models.DomainError
+	if errors.As(err, &de) {
+		// tier ini tidak ada di request, beri tahu client bahwa price_tiers perlu ikut dikirim
+		return de.With("existing_tier", true)
+	}
+	return err
+}
+
+// validateProduct - isi default dan validasi produk sebelum create / update
+func validateProduct(p *models.Product) error {
+	if strings.TrimSpace(p.BaseUnit) == "" {
+		p.BaseUnit = "pcs"
+	}
+	if err := validateWeighted(p); err != nil {
+		return err
+	}
+	if err := validateThreshold(p); err != nil {
+		return err
+	}
+	return validatePriceTiers(p)
+}
+
+// validateThreshold - batas low-stock: min_stock dan reorder_qty tidak boleh negatif
+func validateThreshold(p *models.Product) error {
+	if p.MinStock < 0 || p.ReorderQty < 0 {
+		return models.Invalid("product.min_stock_negative")
+	}
+	return nil
+}
+
+// validatePriceTiers - min_quantity unik dan > 0, harga grosir tidak boleh di atas harga normal
+func validatePriceTiers(p *models.Product) error {
+	seen := make(map[float64]bool)
+	for _, t := range p.PriceTiers {
+		if t.MinQuantity <= 0 {
+			return models.Invalid("price_tier.min_quantity_not_positive")
+		}
+		if !models.HasPrecision(t.MinQuantity, p.QuantityPrecision) {
+			return models.Invalid("price_tier.min_quantity_precision", "precision", p.QuantityPrecision)
+		}
+		if seen[t.MinQuantity] {
+			return models.Invalid("price_tier.duplicate", "min_quantity", t.MinQuantity)
+		}
+		seen[t.MinQuantity] = true
+		if t.Price < 0 || t.Price > p.Price {
+			return models.Invalid("price_tier.invalid_price", "min_quantity", t.MinQuantity)
+		}
+	}
+	return nil
+}
+
+func (s *ProductService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *ProductService) Restore(ctx context.Context, id int) error {
+	return s.repo.Restore(ctx, id)
+}
+
+func (s *ProductService) GetPriceTimeline(ctx context.Context, id int) (*models.PriceTimeline, error) {
+	return s.repo.GetPriceTimeline(ctx, id)
+}
+
+// SchedulePrice - jadwalkan harga baru, effective_at harus di masa depan
+func (s *ProductService) SchedulePrice(ctx context.Context, schedule *models.PriceSchedule) error {
+	if schedule.Price < 0 {
+		return models.Invalid("validation.price_negative")
+	}
+	if schedule.EffectiveAt.IsZero() {
+		return models.Invalid("price_schedule.effective_at_required")
+	}
+	if !schedule.EffectiveAt.After(time.Now()) {
+		return models.Invalid("price_schedule.effective_at_past")
+	}
+	return s.repo.CreatePriceSchedule(ctx, schedule)
+}
+
+func (s *ProductService) CancelPriceSchedule(ctx context.Context, productID, scheduleID int) error {
+	return s.repo.CancelPriceSchedule(ctx, productID, scheduleID)
+}
+
+// RunPriceScheduler - terapkan jadwal harga yang jatuh tempo setiap interval sampai ctx dibatalkan,
+// dipanggil sebagai goroutine
+func (s *ProductService) RunPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		applied, err := s.repo.ApplyDuePriceSchedules(ctx, time.Now())
+		if err != nil {
+			slog.Error("gagal menerapkan jadwal harga", "job", "price-schedule", "err", err)
+		} else if applied > 0 {
+			slog.Info("jadwal harga diterapkan", "job", "price-schedule", "applied", applied)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func validateWeighted(p *models.Product) error {
+	if !p.Weighted {
+		p.QuantityPrecision = 0
+	} else if p.QuantityPrecision == 0 {
+		p.QuantityPrecision = models.MaxQuantityPrecision
+	}
+	if p.QuantityPrecision < 0 || p.QuantityPrecision > models.MaxQuantityPrecision {
+		return models.Invalid("product.invalid_precision", "max", models.MaxQuantityPrecision)
+	}
+	if !models.HasPrecision(p.Stock, p.QuantityPrecision) {
+		return models.Invalid("product.stock_precision", "precision", p.QuantityPrecision)
+	}
+	if p.PLU != "" && !isDigits(p.PLU, 5) {
+		return models.Invalid("product.invalid_plu")
+	}
+	return nil
+}
+
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+	"time"
+)
+
+func TestValidatePriceTiers(t *testing.T) {
+	tiers := []models.PriceTier{{MinQuantity: 10, Price: 9000}, {MinQuantity: 50, Price: 8500}}
+
+	tests := []struct {
+		name    string
+		product models.Product
+		wantKey string
+	}{
+		{name: "valid", product: models.Product{Price: 10000, PriceTiers: tiers}},
+		{name: "tanpa tier", product: models.Product{Price: 10000}},
+		{name: "tier sama dengan harga normal", product: models.Product{Price: 9000, PriceTiers: tiers}},
+		// harga normal turun di bawah tier yang sudah tersimpan
+		{name: "harga turun di bawah tier", product: models.Product{Price: 8800, PriceTiers: tiers}, wantKey: "price_tier.invalid_price"},
+		{name: "min_quantity nol", product: models.Product{Price: 10000, PriceTiers: []models.PriceTier{{MinQuantity: 0, Price: 9000}}}, wantKey: "price_tier.min_quantity_not_positive"},
+		{name: "min_quantity duplikat", product: models.Product{Price: 10000, PriceTiers: []models.PriceTier{{MinQuantity: 5, Price: 9000}, {MinQuantity: 5, Price: 8000}}}, wantKey: "price_tier.duplicate"},
+		{name: "harga tier negatif", product: models.Product{Price: 10000, PriceTiers: []models.PriceTier{{MinQuantity: 5, Price: -1}}}, wantKey: "price_tier.invalid_price"},
+		// produk tidak lagi ditimbang: tier 2.5 kg tidak valid untuk presisi 0
+		{name: "presisi turun", product: models.Product{Price: 10000, PriceTiers: []models.PriceTier{{MinQuantity: 2.5, Price: 9000}}}, wantKey: "price_tier.min_quantity_precision"},
+		{name: "tier pecahan produk timbang", product: models.Product{Price: 10000, QuantityPrecision: 3, PriceTiers: []models.PriceTier{{MinQuantity: 2.5, Price: 9000}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePriceTiers(&tt.product)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("validatePriceTiers() error = %v", err)
+				}
+				return
+			}
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("validatePriceTiers() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestSchedulePriceValidation(t *testing.T) {
+	// semua kasus ditolak sebelum menyentuh repository
+	s := &ProductService{}
+
+	tests := []struct {
+		name     string
+		schedule models.PriceSchedule
+		wantKey  string
+	}{
+		{name: "harga negatif", schedule: models.PriceSchedule{ProductID: 1, Price: -1, EffectiveAt: time.Now().Add(time.Hour)}, wantKey: "validation.price_negative"},
+		{name: "tanpa effective_at", schedule: models.PriceSchedule{ProductID: 1, Price: 5000}, wantKey: "price_schedule.effective_at_required"},
+		{name: "di masa lalu", schedule: models.PriceSchedule{ProductID: 1, Price: 5000, EffectiveAt: time.Now().Add(-time.Minute)}, wantKey: "price_schedule.effective_at_past"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.SchedulePrice(context.Background(), &tt.schedule)
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("SchedulePrice() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestNormalizeProductFilter(t *testing.T) {
+	price := func(v int) *int { return &v }
+
+	tests := []struct {
+		name      string
+		filter    models.ProductFilter
+		wantKey   string
+		wantLimit int
+	}{
+		{name: "limit kosong pakai default", filter: models.ProductFilter{}, wantLimit: models.DefaultProductPageLimit},
+		{name: "limit dalam batas", filter: models.ProductFilter{Limit: 20, Sort: "price", Order: "desc"}, wantLimit: 20},
+		{name: "limit dipotong", filter: models.ProductFilter{Limit: 1000}, wantLimit: models.MaxProductPageLimit},
+		{name: "sort tidak dikenal", filter: models.ProductFilter{Sort: "created_at"}, wantKey: "product_filter.invalid_sort"},
+		{name: "order tidak dikenal", filter: models.ProductFilter{Order: "ASC"}, wantKey: "product_filter.invalid_order"},
+		{name: "offset negatif", filter: models.ProductFilter{Offset: -1}, wantKey: "product_filter.negative_paging"},
+		{name: "limit negatif", filter: models.ProductFilter{Limit: -5}, wantKey: "product_filter.negative_paging"},
+		{name: "rentang harga terbalik", filter: models.ProductFilter{MinPrice: price(5000), MaxPrice: price(1000)}, wantKey: "product_filter.invalid_price_range"},
+		{name: "rentang harga sama", filter: models.ProductFilter{MinPrice: price(5000), MaxPrice: price(5000)}, wantLimit: models.DefaultProductPageLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.filter
+			err := normalizeProductFilter(&f)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("err = %v", err)
+				}
+				if f.Limit != tt.wantLimit {
+					t.Errorf("Limit = %d, ingin %d", f.Limit, tt.wantLimit)
+				}
+				return
+			}
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("err = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestNormalizeSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		term      string
+		limit     int
+		wantTerm  string
+		wantLimit int
+		wantKey   string
+	}{
+		{name: "default limit", term: " indomie ", wantTerm: "indomie", wantLimit: models.DefaultSearchLimit},
+		{name: "limit negatif pakai default", term: "teh", limit: -1, wantTerm: "teh", wantLimit: models.DefaultSearchLimit},
+		{name: "limit dipotong", term: "teh", limit: 500, wantTerm: "teh", wantLimit: models.MaxSearchLimit},
+		{name: "dua karakter multibyte", term: "kü", limit: 5, wantTerm: "kü", wantLimit: 5},
+		{name: "satu karakter", term: "a", wantKey: "search.query_too_short"},
+		{name: "spasi saja", term: "   ", wantKey: "search.query_too_short"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term, limit, err := normalizeSearch(tt.term, tt.limit)
+			if tt.wantKey != "" {
+				var de *models.DomainError
+				if !errors.As(err, &de) || de.Key != tt.wantKey {
+					t.Fatalf("err = %v, ingin key %s", err, tt.wantKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if term != tt.wantTerm || limit != tt.wantLimit {
+				t.Errorf("normalizeSearch() = %q, %d, ingin %q, %d", term, limit, tt.wantTerm, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+)
+
+type PurchaseOrderService struct {
+	repo *repositories.PurchaseOrderRepository
+}
+
+func NewPurchaseOrderService(repo *repositories.PurchaseOrderRepository) *PurchaseOrderService {
+	return &PurchaseOrderService{repo: repo}
+}
+
+func (s *PurchaseOrderService) GetAll(ctx context.Context, status string) ([]models.PurchaseOrder, error) {
+	return s.repo.GetAll(ctx, status)
+}
+
+func (s *PurchaseOrderService) GetByID(ctx context.Context, id int) (*models.PurchaseOrder, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *PurchaseOrderService) Create(ctx context.Context, po *models.PurchaseOrder) error {
+	if po.SupplierID <= 0 {
+		return models.Invalid("purchase_order.supplier_required")
+	}
+	if len(po.Items) == 0 {
+		return models.Invalid("validation.items_empty")
+	}
+
+	seen := make(map[int]bool, len(po.Items))
+	for _, item := range po.Items {
+		if item.ProductID <= 0 {
+			return models.Invalid("validation.product_id_required")
+		}
+		if seen[item.ProductID] {
+			return models.Invalid("validation.product_duplicate", "product_id", item.ProductID)
+		}
+		seen[item.ProductID] = true
+		if item.QuantityOrdered <= 0 {
+			return models.Invalid("purchase_order.quantity_ordered_not_positive", "product_id", item.ProductID)
+		}
+		if item.UnitCost < 0 {
+			return models.Invalid("purchase_order.unit_cost_negative", "product_id", item.ProductID)
+		}
+	}
+
+	return s.repo.Create(ctx, po)
+}
+
+func (s *PurchaseOrderService) Receive(ctx context.Context, id int, req models.ReceiveRequest) (*models.GoodsReceipt, error) {
+	if len(req.Items) == 0 {
+		return nil, models.Invalid("validation.items_empty")
+	}
+	seen := make(map[int]bool, len(req.Items))
+	for _, item := range req.Items {
+		if seen[item.ProductID] {
+			return nil, models.Invalid("validation.product_duplicate", "product_id", item.ProductID)
+		}
+		seen[item.ProductID] = true
+		if item.Quantity <= 0 {
+			return nil, models.Invalid("validation.quantity_not_positive", "product_id", item.ProductID)
+		}
+		if item.UnitCost < 0 {
+			return nil, models.Invalid("purchase_order.unit_cost_negative", "product_id", item.ProductID)
+		}
+	}
+
+	return s.repo.Receive(ctx, id, req)
+}
+
+func (s *PurchaseOrderService) Cancel(ctx context.Context, id int) error {
+	return s.repo.Cancel(ctx, id)
+}
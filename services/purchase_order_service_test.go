@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestPurchaseOrderCreateValidation(t *testing.T) {
+	// semua kasus ditolak sebelum menyentuh repository
+	s := &PurchaseOrderService{}
+	item := func(productID int, qty float64, cost int) models.PurchaseOrderItem {
+		return models.PurchaseOrderItem{ProductID: productID, QuantityOrdered: qty, UnitCost: cost}
+	}
+
+	tests := []struct {
+		name    string
+		po      models.PurchaseOrder
+		wantKey string
+	}{
+		{name: "tanpa supplier", po: models.PurchaseOrder{Items: []models.PurchaseOrderItem{item(1, 1, 100)}}, wantKey: "purchase_order.supplier_required"},
+		{name: "tanpa item", po: models.PurchaseOrder{SupplierID: 1}, wantKey: "validation.items_empty"},
+		{name: "tanpa product_id", po: models.PurchaseOrder{SupplierID: 1, Items: []models.PurchaseOrderItem{item(0, 1, 100)}}, wantKey: "validation.product_id_required"},
+		{name: "produk dobel", po: models.PurchaseOrder{SupplierID: 1, Items: []models.PurchaseOrderItem{item(1, 1, 100), item(1, 2, 100)}}, wantKey: "validation.product_duplicate"},
+		{name: "quantity nol", po: models.PurchaseOrder{SupplierID: 1, Items: []models.PurchaseOrderItem{item(1, 0, 100)}}, wantKey: "purchase_order.quantity_ordered_not_positive"},
+		{name: "quantity negatif pecahan", po: models.PurchaseOrder{SupplierID: 1, Items: []models.PurchaseOrderItem{item(1, -0.5, 100)}}, wantKey: "purchase_order.quantity_ordered_not_positive"},
+		{name: "harga beli negatif", po: models.PurchaseOrder{SupplierID: 1, Items: []models.PurchaseOrderItem{item(1, 2.5, -1)}}, wantKey: "purchase_order.unit_cost_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Create(context.Background(), &tt.po)
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("Create() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderReceiveValidation(t *testing.T) {
+	s := &PurchaseOrderService{}
+
+	tests := []struct {
+		name    string
+		items   []models.GoodsReceiptItem
+		wantKey string
+	}{
+		{name: "tanpa item", wantKey: "validation.items_empty"},
+		{name: "produk dobel", items: []models.GoodsReceiptItem{{ProductID: 1, Quantity: 1}, {ProductID: 1, Quantity: 1}}, wantKey: "validation.product_duplicate"},
+		{name: "quantity nol", items: []models.GoodsReceiptItem{{ProductID: 1}}, wantKey: "validation.quantity_not_positive"},
+		{name: "harga beli negatif", items: []models.GoodsReceiptItem{{ProductID: 1, Quantity: 0.25, UnitCost: -5}}, wantKey: "purchase_order.unit_cost_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Receive(context.Background(), 1, models.ReceiveRequest{Items: tt.items})
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("Receive() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestSupplierNameRequired(t *testing.T) {
+	s := &SupplierService{}
+	for _, name := range []string{"", "  "} {
+		for op, fn := range map[string]func(context.Context, *models.Supplier) error{"Create": s.Create, "Update": s.Update} {
+			err := fn(context.Background(), &models.Supplier{Name: name})
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != "supplier.name_required" {
+				t.Errorf("%s(%q) error = %v, ingin supplier.name_required", op, name, err)
+			}
+		}
+	}
+}
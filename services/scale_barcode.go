@@ -0,0 +1,67 @@
+package services
+
+import (
+	"kasir-api/models"
+	"math"
+	"strconv"
+)
+
+// ScaleBarcodeConfig - format barcode timbangan EAN-13: PP IIIII VVVVV C
+// (PP prefix 20-29, IIIII PLU produk, VVVVV berat atau harga, C check digit)
+type ScaleBarcodeConfig struct {
+	// PricePrefixes - prefix yang VVVVV-nya harga rupiah, prefix 20-29 lainnya dianggap berat
+	PricePrefixes []string
+	// WeightDecimals - jumlah desimal pada nilai berat, 3 = gram -> kg
+	WeightDecimals int
+}
+
+func DefaultScaleBarcodeConfig() ScaleBarcodeConfig {
+	return ScaleBarcodeConfig{WeightDecimals: 3}
+}
+
+// ParseScaleBarcode - isi PLU dan Quantity (atau EmbeddedPrice) pada item dari barcode timbangan
+func ParseScaleBarcode(code string, cfg ScaleBarcodeConfig, item *models.CheckoutItem) error {
+	if !isDigits(code, 13) {
+		return models.Invalid("scale_barcode.not_ean13", "barcode", code)
+	}
+	if code[0] != '2' {
+		return models.Invalid("scale_barcode.invalid_prefix", "barcode", code)
+	}
+	if !validEAN13(code) {
+		return models.Invalid("scale_barcode.invalid_check_digit", "barcode", code)
+	}
+
+	prefix := code[:2]
+	value, err := strconv.Atoi(code[7:12])
+	if err != nil {
+		return err
+	}
+	if value == 0 {
+		return models.Invalid("scale_barcode.zero_value")
+	}
+
+	item.PLU = code[2:7]
+	for _, p := range cfg.PricePrefixes {
+		if p == prefix {
+			item.EmbeddedPrice = value
+			item.Quantity = 0
+			return nil
+		}
+	}
+	item.Quantity = float64(value) / math.Pow10(cfg.WeightDecimals)
+	return nil
+}
+
+// validEAN13 - bobot 1 untuk digit ganjil, 3 untuk digit genap (dari kiri, 1-based)
+func validEAN13(code string) bool {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(code[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	check := (10 - sum%10) % 10
+	return check == int(code[12]-'0')
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestParseScaleBarcode(t *testing.T) {
+	cfg := ScaleBarcodeConfig{PricePrefixes: []string{"28", "29"}, WeightDecimals: 3}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantKey  string
+		plu      string
+		quantity float64
+		price    int
+	}{
+		{name: "berat gram ke kg", code: "2001234012348", plu: "01234", quantity: 1.234},
+		// nilai 00000 lolos check digit tapi ditolak
+		{name: "nilai nol", code: "2100001000004", wantKey: "scale_barcode.zero_value"},
+		{name: "prefix 22 berat", code: "2200005125007", plu: "00005", quantity: 12.5},
+		{name: "prefix harga", code: "2812345015001", plu: "12345", price: 1500},
+		{name: "prefix harga 29", code: "2900001000017", plu: "00001", price: 1},
+		{name: "check digit salah", code: "2001234012349", wantKey: "scale_barcode.invalid_check_digit"},
+		{name: "bukan prefix timbangan", code: "4006381333931", wantKey: "scale_barcode.invalid_prefix"},
+		{name: "terlalu pendek", code: "200123401234", wantKey: "scale_barcode.not_ean13"},
+		{name: "terlalu panjang", code: "20012340123480", wantKey: "scale_barcode.not_ean13"},
+		{name: "bukan angka", code: "20012340123A8", wantKey: "scale_barcode.not_ean13"},
+		{name: "kosong", code: "", wantKey: "scale_barcode.not_ean13"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// quantity lama dari request harus tertimpa
+			item := models.CheckoutItem{Quantity: 99}
+			err := ParseScaleBarcode(tt.code, cfg, &item)
+			if tt.wantKey != "" {
+				var de *models.DomainError
+				if !errors.As(err, &de) || de.Key != tt.wantKey {
+					t.Fatalf("err = %v, want key %s", err, tt.wantKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if item.PLU != tt.plu || item.Quantity != tt.quantity || item.EmbeddedPrice != tt.price {
+				t.Errorf("got plu=%s quantity=%v price=%d, want plu=%s quantity=%v price=%d",
+					item.PLU, item.Quantity, item.EmbeddedPrice, tt.plu, tt.quantity, tt.price)
+			}
+		})
+	}
+}
+
+func TestValidEAN13(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"4006381333931", true},
+		{"2001234012348", true},
+		{"0000000000000", true},
+		{"4006381333932", false},
+		{"2001234012340", false},
+		// dua digit bersebelahan tertukar: bobot 1/3 harus menangkapnya
+		{"2002134012348", false},
+	}
+	for _, tt := range tests {
+		if got := validEAN13(tt.code); got != tt.want {
+			t.Errorf("validEAN13(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
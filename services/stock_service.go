@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type StockService struct {
+	repo *repositories.StockRepository
+}
+
+func NewStockService(repo *repositories.StockRepository) *StockService {
+	return &StockService{repo: repo}
+}
+
+func invalidReasonError(reason string) error {
+	return models.Invalid("stock.invalid_reason", "reason", reason, "allowed", models.AdjustmentReasons)
+}
+
+func (s *StockService) Adjust(ctx context.Context, req models.StockAdjustmentRequest) (*models.StockMovement, error) {
+	if req.ProductID <= 0 {
+		return nil, models.Invalid("validation.product_id_required")
+	}
+	if req.Quantity == 0 {
+		return nil, models.Invalid("stock.quantity_zero")
+	}
+	if !models.IsValidAdjustmentReason(req.Reason) {
+		return nil, invalidReasonError(req.Reason)
+	}
+	// mis. barang rusak tidak mungkin menambah stok, barang ditemukan tidak mungkin mengurangi
+	switch sign := models.AdjustmentSign(req.Reason); {
+	case sign < 0 && req.Quantity > 0:
+		return nil, models.Invalid("stock.reason_requires_negative", "reason", req.Reason)
+	case sign > 0 && req.Quantity < 0:
+		return nil, models.Invalid("stock.reason_requires_positive", "reason", req.Reason)
+	}
+	return s.repo.CreateAdjustment(ctx, req)
+}
+
+func (s *StockService) GetMovements(ctx context.Context, productID int) ([]models.StockMovement, error) {
+	return s.repo.GetMovements(ctx, productID)
+}
+
+func (s *StockService) CreateStockTake(ctx context.Context, st *models.StockTake) error {
+	return s.repo.CreateStockTake(ctx, st)
+}
+
+func (s *StockService) GetAllStockTakes(ctx context.Context) ([]models.StockTake, error) {
+	return s.repo.GetAllStockTakes(ctx)
+}
+
+func (s *StockService) GetStockTakeByID(ctx context.Context, id int) (*models.StockTake, error) {
+	return s.repo.GetStockTakeByID(ctx, id)
+}
+
+func (s *StockService) SubmitCounts(ctx context.Context, id int, items []models.StockCountItem) (*models.StockTake, error) {
+	if len(items) == 0 {
+		return nil, models.Invalid("validation.items_empty")
+	}
+	for _, item := range items {
+		if item.ProductID <= 0 {
+			return nil, models.Invalid("validation.product_id_required")
+		}
+		if item.CountedStock < 0 {
+			return nil, models.Invalid("stock_take.counted_negative", "product_id", item.ProductID)
+		}
+		if item.Reason != "" && !models.IsValidAdjustmentReason(item.Reason) {
+			return nil, invalidReasonError(item.Reason)
+		}
+	}
+
+	if err := s.repo.SaveCounts(ctx, id, items); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStockTakeByID(ctx, id)
+}
+
+func (s *StockService) PostStockTake(ctx context.Context, id int, postedBy string) (*models.StockTake, error) {
+	if strings.TrimSpace(postedBy) == "" {
+		return nil, models.Invalid("stock_take.posted_by_required")
+	}
+	if err := s.repo.PostStockTake(ctx, id, postedBy); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStockTakeByID(ctx, id)
+}
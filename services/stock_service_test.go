@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestStockAdjustValidation(t *testing.T) {
+	// semua kasus ditolak sebelum menyentuh repository
+	s := &StockService{}
+
+	tests := []struct {
+		name     string
+		reason   string
+		quantity float64
+		wantKey  string
+	}{
+		{name: "quantity nol", reason: models.ReasonCorrection, quantity: 0, wantKey: "stock.quantity_zero"},
+		{name: "alasan tidak dikenal", reason: "stolen", quantity: -1, wantKey: "stock.invalid_reason"},
+		{name: "received bukan alasan manual", reason: models.ReasonReceived, quantity: 5, wantKey: "stock.invalid_reason"},
+		{name: "rusak menambah stok", reason: models.ReasonDamaged, quantity: 2, wantKey: "stock.reason_requires_negative"},
+		{name: "hilang menambah stok", reason: models.ReasonLost, quantity: 0.5, wantKey: "stock.reason_requires_negative"},
+		{name: "kedaluwarsa menambah stok", reason: models.ReasonExpired, quantity: 1, wantKey: "stock.reason_requires_negative"},
+		{name: "ditemukan mengurangi stok", reason: models.ReasonFound, quantity: -3, wantKey: "stock.reason_requires_positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Adjust(context.Background(), models.StockAdjustmentRequest{ProductID: 1, Quantity: tt.quantity, Reason: tt.reason})
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("Adjust() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestAdjustmentSign(t *testing.T) {
+	want := map[string]int{
+		models.ReasonDamaged:    -1,
+		models.ReasonLost:       -1,
+		models.ReasonExpired:    -1,
+		models.ReasonFound:      1,
+		models.ReasonCorrection: 0,
+	}
+	// setiap alasan manual harus punya arah yang jelas di tabel
+	for _, reason := range models.AdjustmentReasons {
+		w, ok := want[reason]
+		if !ok {
+			t.Errorf("alasan %q belum ada di tes", reason)
+			continue
+		}
+		if got := models.AdjustmentSign(reason); got != w {
+			t.Errorf("AdjustmentSign(%q) = %d, ingin %d", reason, got, w)
+		}
+	}
+}
+
+func TestSubmitCountsValidation(t *testing.T) {
+	s := &StockService{}
+
+	tests := []struct {
+		name    string
+		items   []models.StockCountItem
+		wantKey string
+	}{
+		{name: "tanpa item", wantKey: "validation.items_empty"},
+		{name: "tanpa product_id", items: []models.StockCountItem{{CountedStock: 3}}, wantKey: "validation.product_id_required"},
+		{name: "hitungan negatif", items: []models.StockCountItem{{ProductID: 1, CountedStock: -1}}, wantKey: "stock_take.counted_negative"},
+		{name: "alasan tidak dikenal", items: []models.StockCountItem{{ProductID: 1, CountedStock: 2, Reason: "stolen"}}, wantKey: "stock.invalid_reason"},
+		// item kedua yang salah tetap menolak seluruh request
+		{name: "item kedua salah", items: []models.StockCountItem{{ProductID: 1, CountedStock: 2}, {ProductID: 2, CountedStock: -0.5}}, wantKey: "stock_take.counted_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.SubmitCounts(context.Background(), 1, tt.items)
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("SubmitCounts() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestPostStockTakeRequiresPostedBy(t *testing.T) {
+	s := &StockService{}
+	for _, postedBy := range []string{"", "   "} {
+		_, err := s.PostStockTake(context.Background(), 1, postedBy)
+		var de *models.DomainError
+		if !errors.As(err, &de) || de.Key != "stock_take.posted_by_required" {
+			t.Errorf("PostStockTake(%q) error = %v, ingin stock_take.posted_by_required", postedBy, err)
+		}
+	}
+}
+
+func TestIsValidAdjustmentReason(t *testing.T) {
+	for _, reason := range models.AdjustmentReasons {
+		if !models.IsValidAdjustmentReason(reason) {
+			t.Errorf("IsValidAdjustmentReason(%q) = false", reason)
+		}
+	}
+	// received khusus penerimaan PO, tidak boleh dipakai penyesuaian manual
+	for _, reason := range []string{models.ReasonReceived, "", "DAMAGED"} {
+		if models.IsValidAdjustmentReason(reason) {
+			t.Errorf("IsValidAdjustmentReason(%q) = true", reason)
+		}
+	}
+}
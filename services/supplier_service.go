@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type SupplierService struct {
+	repo *repositories.SupplierRepository
+}
+
+func NewSupplierService(repo *repositories.SupplierRepository) *SupplierService {
+	return &SupplierService{repo: repo}
+}
+
+func (s *SupplierService) GetAll(ctx context.Context) ([]models.Supplier, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *SupplierService) Create(ctx context.Context, data *models.Supplier) error {
+	if strings.TrimSpace(data.Name) == "" {
+		return models.Invalid("supplier.name_required")
+	}
+	return s.repo.Create(ctx, data)
+}
+
+func (s *SupplierService) GetByID(ctx context.Context, id int) (*models.Supplier, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *SupplierService) Update(ctx context.Context, data *models.Supplier) error {
+	if strings.TrimSpace(data.Name) == "" {
+		return models.Invalid("supplier.name_required")
+	}
+	return s.repo.Update(ctx, data)
+}
+
+func (s *SupplierService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -4,24 +4,124 @@ import (
 	"context"
 	"kasir-api/models"
 	"kasir-api/repositories"
+	"sync"
+	"time"
 )
 
 type TransactionService struct {
-	repo *repositories.TransactionRepository
+	repo      *repositories.TransactionRepository
+	notifiers []LowStockNotifier
+	observers []CheckoutObserver
+	scale     ScaleBarcodeConfig
+	// location - zona waktu toko untuk batas "hari ini"
+	location *time.Location
+
+	// notifying - notifikasi stok menipis yang masih dikirim, ditunggu saat shutdown
+	notifying sync.WaitGroup
 }
 
 func NewTransactionService(repo *repositories.TransactionRepository) *TransactionService {
-	return &TransactionService{repo: repo}
+	return &TransactionService{repo: repo, scale: DefaultScaleBarcodeConfig(), location: time.Local}
+}
+
+// SetLocation - zona waktu toko, server bisa saja jalan di UTC
+func (s *TransactionService) SetLocation(loc *time.Location) {
+	s.location = loc
+}
+
+// SetScaleBarcodeConfig - ganti format barcode timbangan yang dipakai toko
+func (s *TransactionService) SetScaleBarcodeConfig(cfg ScaleBarcodeConfig) {
+	s.scale = cfg
 }
 
-func (s *TransactionService) Checkout(items []models.CheckoutItem) (*models.Transaction, error) {
-	return s.repo.CreateTransaction(items)
+// OnLowStock - daftarkan notifier untuk event stok menipis setelah checkout
+func (s *TransactionService) OnLowStock(n LowStockNotifier) {
+	s.notifiers = append(s.notifiers, n)
+}
+
+// OnCheckout - daftarkan observer yang dipanggil setelah setiap checkout, berhasil maupun gagal
+func (s *TransactionService) OnCheckout(o CheckoutObserver) {
+	s.observers = append(s.observers, o)
+}
+
+func (s *TransactionService) Checkout(ctx context.Context, req models.CheckoutRequest) (*models.Transaction, error) {
+	transaction, err := s.checkout(ctx, req)
+	for _, o := range s.observers {
+		o(ctx, transaction, err)
+	}
+	return transaction, err
+}
+
+func (s *TransactionService) checkout(ctx context.Context, req models.CheckoutRequest) (*models.Transaction, error) {
+	items := req.Items
+	if len(items) == 0 {
+		return nil, models.Invalid("validation.items_empty")
+	}
+	for i := range items {
+		item := &items[i]
+		if item.Barcode != "" {
+			// barcode menentukan produk lewat PLU, product_id yang ikut dikirim bisa menunjuk produk lain
+			if item.ProductID != 0 {
+				return nil, models.Invalid("checkout.product_id_with_barcode", "product_id", item.ProductID, "barcode", item.Barcode)
+			}
+			if err := ParseScaleBarcode(item.Barcode, s.scale, item); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if item.Quantity <= 0 {
+			return nil, models.Invalid("validation.quantity_not_positive", "product_id", item.ProductID)
+		}
+	}
+
+	transaction, alerts, err := s.repo.CreateTransaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// notifikasi dikirim setelah commit, tidak boleh memperlambat response kasir
+	for _, alert := range alerts {
+		for _, n := range s.notifiers {
+			s.notifying.Add(1)
+			go func() {
+				defer s.notifying.Done()
+				n(alert)
+			}()
+		}
+	}
+
+	return transaction, nil
+}
+
+// Drain - tunggu notifikasi stok menipis yang masih dikirim selesai, dipanggil saat shutdown
+func (s *TransactionService) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.notifying.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (s *TransactionService) GetSummaryToday(ctx context.Context) (*models.SummaryToday, error) {
-	return s.repo.GetSummaryToday(ctx)
+	from, to := s.today()
+	return s.repo.GetSummaryToday(ctx, from, to)
 }
 
 func (s *TransactionService) GetBestSellerToday(ctx context.Context) (string, int, error) {
-	return s.repo.GetBestSellerToday(ctx)
+	from, to := s.today()
+	return s.repo.GetBestSellerToday(ctx, from, to)
+}
+
+// today - awal hari ini dan awal besok di zona waktu toko
+func (s *TransactionService) today() (time.Time, time.Time) {
+	now := time.Now().In(s.location)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.location)
+	return from, from.AddDate(0, 0, 1)
 }
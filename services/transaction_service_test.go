@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestCheckoutItemValidation(t *testing.T) {
+	// semua kasus ditolak sebelum menyentuh repository
+	s := &TransactionService{scale: DefaultScaleBarcodeConfig()}
+
+	tests := []struct {
+		name    string
+		items   []models.CheckoutItem
+		wantKey string
+	}{
+		{name: "tanpa item", wantKey: "validation.items_empty"},
+		{name: "quantity nol", items: []models.CheckoutItem{{ProductID: 1}}, wantKey: "validation.quantity_not_positive"},
+		{name: "quantity negatif", items: []models.CheckoutItem{{ProductID: 1, Quantity: -2}}, wantKey: "validation.quantity_not_positive"},
+		{name: "product_id dan barcode", items: []models.CheckoutItem{{ProductID: 1, Barcode: "2001234012348"}}, wantKey: "checkout.product_id_with_barcode"},
+		{name: "barcode tidak valid", items: []models.CheckoutItem{{Barcode: "2001234012349"}}, wantKey: "scale_barcode.invalid_check_digit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.checkout(context.Background(), models.CheckoutRequest{Items: tt.items})
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("checkout() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
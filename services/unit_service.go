@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type UnitService struct {
+	repo *repositories.UnitRepository
+}
+
+func NewUnitService(repo *repositories.UnitRepository) *UnitService {
+	return &UnitService{repo: repo}
+}
+
+func (s *UnitService) GetByProduct(ctx context.Context, productID int) ([]models.ProductUnit, error) {
+	return s.repo.GetByProduct(ctx, productID)
+}
+
+func (s *UnitService) Create(ctx context.Context, data *models.ProductUnit) error {
+	if data.ProductID <= 0 {
+		return models.Invalid("validation.product_id_required")
+	}
+	if err := validateUnit(data); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, data)
+}
+
+func (s *UnitService) GetByID(ctx context.Context, id int) (*models.ProductUnit, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *UnitService) Update(ctx context.Context, data *models.ProductUnit) error {
+	if err := validateUnit(data); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, data)
+}
+
+func (s *UnitService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func validateUnit(u *models.ProductUnit) error {
+	u.Name = strings.TrimSpace(u.Name)
+	if u.Name == "" {
+		return models.Invalid("unit.name_required")
+	}
+	if u.ConversionFactor <= 1 {
+		return models.Invalid("unit.invalid_conversion_factor")
+	}
+	if u.Price < 0 {
+		return models.Invalid("validation.price_negative")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestValidateUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		unit    models.ProductUnit
+		wantKey string
+	}{
+		{name: "valid", unit: models.ProductUnit{Name: " dus ", ConversionFactor: 24, Price: 80000}},
+		{name: "harga 0 = harga dasar x factor", unit: models.ProductUnit{Name: "pack", ConversionFactor: 6}},
+		{name: "nama kosong", unit: models.ProductUnit{Name: "  ", ConversionFactor: 24}, wantKey: "unit.name_required"},
+		// factor 1 sama dengan satuan dasar
+		{name: "factor 1", unit: models.ProductUnit{Name: "biji", ConversionFactor: 1}, wantKey: "unit.invalid_conversion_factor"},
+		{name: "factor 0", unit: models.ProductUnit{Name: "dus"}, wantKey: "unit.invalid_conversion_factor"},
+		{name: "harga negatif", unit: models.ProductUnit{Name: "dus", ConversionFactor: 24, Price: -1}, wantKey: "validation.price_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUnit(&tt.unit)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("validateUnit() error = %v", err)
+				}
+				if tt.unit.Name != "dus" && tt.unit.Name != "pack" {
+					t.Errorf("nama tidak di-trim: %q", tt.unit.Name)
+				}
+				return
+			}
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("validateUnit() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestUnitCreateRequiresProduct(t *testing.T) {
+	err := (&UnitService{}).Create(context.Background(), &models.ProductUnit{Name: "dus", ConversionFactor: 24})
+	var de *models.DomainError
+	if !errors.As(err, &de) || de.Key != "validation.product_id_required" {
+		t.Fatalf("Create() error = %v, ingin validation.product_id_required", err)
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"kasir-api/models"
+	"kasir-api/repositories"
+	"strings"
+)
+
+type VariantService struct {
+	repo *repositories.VariantRepository
+}
+
+func NewVariantService(repo *repositories.VariantRepository) *VariantService {
+	return &VariantService{repo: repo}
+}
+
+func (s *VariantService) GetByProduct(ctx context.Context, productID int) ([]models.ProductVariant, error) {
+	return s.repo.GetByProduct(ctx, productID)
+}
+
+func (s *VariantService) Create(ctx context.Context, data *models.ProductVariant) error {
+	if data.ProductID <= 0 {
+		return models.Invalid("validation.product_id_required")
+	}
+	if err := validateVariant(data); err != nil {
+		return err
+	}
+	if data.Stock < 0 {
+		return models.Invalid("product.price_stock_negative")
+	}
+	return s.repo.Create(ctx, data)
+}
+
+func (s *VariantService) GetByID(ctx context.Context, id int) (*models.ProductVariant, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *VariantService) Update(ctx context.Context, data *models.ProductVariant) error {
+	if err := validateVariant(data); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, data)
+}
+
+func (s *VariantService) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func validateVariant(v *models.ProductVariant) error {
+	v.SKU = strings.TrimSpace(v.SKU)
+	if strings.TrimSpace(v.Name) == "" {
+		return models.Invalid("variant.name_required")
+	}
+	if v.SKU == "" {
+		return models.Invalid("variant.sku_required")
+	}
+	if v.Price < 0 {
+		return models.Invalid("product.price_stock_negative")
+	}
+	return nil
+}
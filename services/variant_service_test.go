@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kasir-api/models"
+	"testing"
+)
+
+func TestValidateVariant(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant models.ProductVariant
+		wantKey string
+	}{
+		{name: "valid", variant: models.ProductVariant{Name: "L", SKU: " TS-L ", Price: 50000}},
+		{name: "nama kosong", variant: models.ProductVariant{Name: "  ", SKU: "TS-L"}, wantKey: "variant.name_required"},
+		{name: "sku kosong", variant: models.ProductVariant{Name: "L", SKU: " "}, wantKey: "variant.sku_required"},
+		{name: "harga negatif", variant: models.ProductVariant{Name: "L", SKU: "TS-L", Price: -1}, wantKey: "product.price_stock_negative"},
+		// stok tidak diubah lewat PUT, jadi tidak ikut divalidasi di sini
+		{name: "stok diabaikan", variant: models.ProductVariant{Name: "L", SKU: "TS-L", Stock: -5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVariant(&tt.variant)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("validateVariant() error = %v", err)
+				}
+				if tt.variant.SKU != "TS-L" {
+					t.Errorf("SKU = %q, ingin di-trim jadi TS-L", tt.variant.SKU)
+				}
+				return
+			}
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("validateVariant() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestVariantCreateValidation(t *testing.T) {
+	// semua kasus ditolak sebelum menyentuh repository
+	s := &VariantService{}
+
+	tests := []struct {
+		name    string
+		variant models.ProductVariant
+		wantKey string
+	}{
+		{name: "tanpa product_id", variant: models.ProductVariant{Name: "L", SKU: "TS-L"}, wantKey: "validation.product_id_required"},
+		{name: "nama kosong", variant: models.ProductVariant{ProductID: 1, SKU: "TS-L"}, wantKey: "variant.name_required"},
+		// stok awal hanya bisa diisi saat create, tetap tidak boleh negatif
+		{name: "stok awal negatif", variant: models.ProductVariant{ProductID: 1, Name: "L", SKU: "TS-L", Stock: -1}, wantKey: "product.price_stock_negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Create(context.Background(), &tt.variant)
+			var de *models.DomainError
+			if !errors.As(err, &de) || de.Key != tt.wantKey {
+				t.Fatalf("Create() error = %v, ingin key %s", err, tt.wantKey)
+			}
+		})
+	}
+}